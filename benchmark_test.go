@@ -0,0 +1,114 @@
+package money
+
+import (
+    "testing"
+    "unsafe"
+)
+
+// benchmarkSliceSize is the number of values in the slices summed by the benchmarks
+const benchmarkSliceSize = 100000
+
+func benchmarkSlice(b *testing.B) MoneySlice {
+    slice := make(MoneySlice, benchmarkSliceSize)
+    for i := range slice {
+        m, err := New(int64(i%1000), "EUR")
+        if err != nil {
+            b.Fatal(err)
+        }
+        slice[i] = m
+    }
+    return slice
+}
+
+func benchmarkValues(b *testing.B) []Money {
+    values := make([]Money, benchmarkSliceSize)
+    for i := range values {
+        m, err := NewValue(int64(i%1000), "EUR")
+        if err != nil {
+            b.Fatal(err)
+        }
+        values[i] = m
+    }
+    return values
+}
+
+func BenchmarkSum(b *testing.B) {
+    slice := benchmarkSlice(b)
+    b.ReportAllocs()
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        if _, err := Sum(slice); err != nil {
+            b.Fatal(err)
+        }
+    }
+}
+
+func BenchmarkSumValues(b *testing.B) {
+    values := benchmarkValues(b)
+    b.ReportAllocs()
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        if _, err := SumValues(values); err != nil {
+            b.Fatal(err)
+        }
+    }
+    b.ReportMetric(float64(unsafe.Sizeof(Money{})), "bytes/value")
+}
+
+func BenchmarkAdd(b *testing.B) {
+    slice := benchmarkSlice(b)
+    b.ReportAllocs()
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        total := slice[0]
+        for _, m := range slice[1:] {
+            var err error
+            if total, err = total.Add(m); err != nil {
+                b.Fatal(err)
+            }
+        }
+    }
+}
+
+func BenchmarkAddValue(b *testing.B) {
+    values := benchmarkValues(b)
+    b.ReportAllocs()
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        total := values[0]
+        for _, m := range values[1:] {
+            var err error
+            if total, err = total.AddValue(m); err != nil {
+                b.Fatal(err)
+            }
+        }
+    }
+}
+
+func BenchmarkEquals(b *testing.B) {
+    slice := benchmarkSlice(b)
+    b.ReportAllocs()
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        for j := 1; j < len(slice); j++ {
+            if _, err := slice[j].Equals(slice[j-1]); err != nil {
+                b.Fatal(err)
+            }
+        }
+    }
+}
+
+func BenchmarkEqualsOperator(b *testing.B) {
+    values := benchmarkValues(b)
+    b.ReportAllocs()
+    b.ResetTimer()
+    equal := 0
+    for i := 0; i < b.N; i++ {
+        for j := 1; j < len(values); j++ {
+            if values[j] == values[j-1] {
+                equal++
+            }
+        }
+    }
+    _ = equal
+}
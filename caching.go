@@ -0,0 +1,229 @@
+package money
+
+import (
+    "sync"
+    "sync/atomic"
+    "time"
+)
+
+// cacheKey identifies a cached lookup
+type cacheKey struct {
+    pair CurrencyPair
+    date string // UTC instant, or UTC day when grouping by day; "" for the latest rate
+}
+
+// cacheEntry is a cached rate or failure with its expiry time
+type cacheEntry struct {
+    rate    float64
+    err     error
+    expires time.Time
+    seq     uint64 // Insertion number, matching its record in the eviction order
+}
+
+// cacheRecord is an entry's position in the eviction order
+type cacheRecord struct {
+    key cacheKey
+    seq uint64
+}
+
+// cacheCall is an upstream lookup in progress that concurrent callers wait on
+type cacheCall struct {
+    done chan struct{}
+    rate float64
+    err  error
+}
+
+// CacheStats reports CachingConverter activity
+type CacheStats struct {
+    Hits      uint64 // Lookups answered from the cache
+    Misses    uint64 // Lookups sent to the wrapped converter
+    Coalesced uint64 // Lookups that waited on an identical lookup already in flight
+    Entries   int    // Cached rates and failures, including expired ones not yet evicted
+}
+
+// CachingConverter wraps a CurrencyConverter and caches its rates per pair and date.
+// Successful lookups are kept for the TTL and failures for the negative TTL, and
+// concurrent identical lookups share a single upstream call. Entries are evicted
+// oldest first as new ones are inserted, once they expire or when the cache holds
+// more than MaxEntries. It is safe for concurrent use.
+//
+// The zero value wraps DefaultConverter and caches nothing, but still coalesces
+// concurrent identical lookups; use NewCachingConverter to set the converter and TTLs.
+type CachingConverter struct {
+    MaxEntries int // Maximum number of cached rates and failures; zero means unlimited
+
+    // GroupByDay caches dated lookups per UTC calendar day rather than per instant.
+    // The wrapped converter is then asked for midnight UTC of that day, so it suits
+    // converters whose rates change at most daily at UTC midnight.
+    GroupByDay bool
+
+    converter   CurrencyConverter
+    ttl         time.Duration
+    negativeTTL time.Duration
+    now         func() time.Time
+
+    mu       sync.Mutex
+    entries  map[cacheKey]cacheEntry
+    order    []cacheRecord // Insertion order, including records of entries since removed
+    seq      uint64
+    inflight map[cacheKey]*cacheCall
+
+    hits      atomic.Uint64
+    misses    atomic.Uint64
+    coalesced atomic.Uint64
+}
+
+// NewCachingConverter wraps converter with a cache. A negativeTTL of zero disables
+// caching of failures.
+func NewCachingConverter(converter CurrencyConverter, ttl, negativeTTL time.Duration) *CachingConverter {
+    return &CachingConverter{
+        converter:   converter,
+        ttl:         ttl,
+        negativeTTL: negativeTTL,
+        now:         time.Now,
+        entries:     make(map[cacheKey]cacheEntry),
+        inflight:    make(map[cacheKey]*cacheCall),
+    }
+}
+
+// GetRate implements CurrencyConverter, answering from the cache when possible
+func (c *CachingConverter) GetRate(from, to string, date *time.Time) (float64, error) {
+    key := cacheKey{pair: CurrencyPair{From: from, To: to}}
+    if date != nil {
+        if c.GroupByDay {
+            year, month, day := date.UTC().Date()
+            normalized := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+            date = &normalized
+        }
+        key.date = date.UTC().Format(time.RFC3339Nano)
+    }
+
+    c.mu.Lock()
+    if c.entries == nil {
+        c.entries = make(map[cacheKey]cacheEntry)
+    }
+    if c.inflight == nil {
+        c.inflight = make(map[cacheKey]*cacheCall)
+    }
+    if entry, ok := c.entries[key]; ok {
+        if c.clock().Before(entry.expires) {
+            c.mu.Unlock()
+            c.hits.Add(1)
+            return entry.rate, entry.err
+        }
+        delete(c.entries, key)
+    }
+    if call, ok := c.inflight[key]; ok {
+        c.mu.Unlock()
+        c.coalesced.Add(1)
+        <-call.done
+        return call.rate, call.err
+    }
+    call := &cacheCall{done: make(chan struct{})}
+    c.inflight[key] = call
+    c.mu.Unlock()
+    c.misses.Add(1)
+
+    c.fetch(key, call, date)
+    return call.rate, call.err
+}
+
+// fetch performs the upstream lookup for a call and publishes its result,
+// releasing waiters even if the wrapped converter panics
+func (c *CachingConverter) fetch(key cacheKey, call *cacheCall, date *time.Time) {
+    defer func() {
+        c.mu.Lock()
+        delete(c.inflight, key)
+        ttl := c.ttl
+        if call.err != nil {
+            ttl = c.negativeTTL
+        }
+        if ttl > 0 {
+            c.insert(key, cacheEntry{rate: call.rate, err: call.err, expires: c.clock().Add(ttl)})
+        }
+        c.mu.Unlock()
+        close(call.done)
+    }()
+
+    // Reported to waiters if the lookup below does not return
+    call.err = &ValidationError{
+        Field:   "converter",
+        Message: "rate lookup did not complete",
+    }
+    converter := c.upstream()
+    if converter == nil {
+        call.err = &ValidationError{
+            Field:   "converter",
+            Message: "no currency converter configured",
+        }
+        return
+    }
+    call.rate, call.err = converter.GetRate(key.pair.From, key.pair.To, date)
+}
+
+// upstream returns the wrapped converter, or DefaultConverter when none is set
+func (c *CachingConverter) upstream() CurrencyConverter {
+    if c.converter != nil {
+        return c.converter
+    }
+    return DefaultConverter
+}
+
+// clock returns the current time
+func (c *CachingConverter) clock() time.Time {
+    if c.now != nil {
+        return c.now()
+    }
+    return time.Now()
+}
+
+// insert stores an entry and evicts the oldest entries that have expired or exceed
+// MaxEntries. The caller must hold c.mu.
+func (c *CachingConverter) insert(key cacheKey, entry cacheEntry) {
+    c.seq++
+    entry.seq = c.seq
+    c.entries[key] = entry
+    c.order = append(c.order, cacheRecord{key: key, seq: entry.seq})
+
+    now := c.clock()
+    for len(c.order) > 0 {
+        oldest := c.order[0]
+        current, ok := c.entries[oldest.key]
+        if ok && current.seq == oldest.seq {
+            if now.Before(current.expires) && (c.MaxEntries <= 0 || len(c.entries) <= c.MaxEntries) {
+                break
+            }
+            delete(c.entries, oldest.key)
+        }
+        c.order = c.order[1:]
+    }
+}
+
+// Stats returns the cache counters
+func (c *CachingConverter) Stats() CacheStats {
+    c.mu.Lock()
+    entries := len(c.entries)
+    c.mu.Unlock()
+    return CacheStats{
+        Hits:      c.hits.Load(),
+        Misses:    c.misses.Load(),
+        Coalesced: c.coalesced.Load(),
+        Entries:   entries,
+    }
+}
+
+// Purge removes all cached rates and failures
+func (c *CachingConverter) Purge() {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    c.entries = make(map[cacheKey]cacheEntry)
+    c.order = nil
+}
+
+// ProviderName implements NamedProvider using the wrapped converter's name
+func (c *CachingConverter) ProviderName() string {
+    if converter := c.upstream(); converter != nil {
+        return providerName(converter)
+    }
+    return "CachingConverter"
+}
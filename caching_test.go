@@ -0,0 +1,303 @@
+package money
+
+import (
+    "errors"
+    "sync"
+    "testing"
+    "time"
+)
+
+// countingConverter is a CurrencyConverter that records its lookups. EUR/USD is
+// 1.1 from 2024-01-02 UTC and 1.0 before; other pairs fail. When release is set,
+// lookups wait on it before answering.
+type countingConverter struct {
+    mu      sync.Mutex
+    dates   []*time.Time
+    release chan struct{}
+}
+
+func (c *countingConverter) GetRate(from, to string, date *time.Time) (float64, error) {
+    recorded := date
+    if date != nil {
+        copied := *date
+        recorded = &copied
+    }
+    c.mu.Lock()
+    c.dates = append(c.dates, recorded)
+    c.mu.Unlock()
+    if c.release != nil {
+        <-c.release
+    }
+    if from != "EUR" || to != "USD" {
+        return 0, &RateNotFoundError{From: from, To: to, Date: date}
+    }
+    if date != nil && date.Before(day(2024, 1, 2)) {
+        return 1.0, nil
+    }
+    return 1.1, nil
+}
+
+func (c *countingConverter) calls() int {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    return len(c.dates)
+}
+
+// fakeClock is a settable time source for cache expiry
+type fakeClock struct {
+    now time.Time
+}
+
+func (f *fakeClock) Now() time.Time { return f.now }
+
+func newTestCache(upstream CurrencyConverter, ttl, negativeTTL time.Duration) (*CachingConverter, *fakeClock) {
+    clock := &fakeClock{now: day(2024, 6, 1)}
+    cache := NewCachingConverter(upstream, ttl, negativeTTL)
+    cache.now = clock.Now
+    return cache, clock
+}
+
+func TestCachingConverterDateKeys(t *testing.T) {
+    plus2 := time.FixedZone("UTC+2", 2*3600)
+    localMidnight := time.Date(2024, 1, 2, 0, 0, 0, 0, plus2) // 2024-01-01 22:00 UTC
+    utcMidnight := day(2024, 1, 2)
+    utcMorning := day(2024, 1, 2).Add(9 * time.Hour)
+
+    tests := []struct {
+        name       string
+        groupByDay bool
+        dates      []time.Time
+        want       []float64
+        wantCalls  int
+        wantDates  []time.Time // Dates passed upstream, in order
+    }{
+        {
+            name:      "UTC+2 midnight is the previous UTC day",
+            dates:     []time.Time{localMidnight, utcMidnight},
+            want:      []float64{1.0, 1.1},
+            wantCalls: 2,
+            wantDates: []time.Time{localMidnight, utcMidnight},
+        },
+        {
+            name:      "same instant in different zones shares an entry",
+            dates:     []time.Time{localMidnight, localMidnight.UTC()},
+            want:      []float64{1.0, 1.0},
+            wantCalls: 1,
+            wantDates: []time.Time{localMidnight},
+        },
+        {
+            name:      "different instants on one day are separate",
+            dates:     []time.Time{utcMidnight, utcMorning},
+            want:      []float64{1.1, 1.1},
+            wantCalls: 2,
+            wantDates: []time.Time{utcMidnight, utcMorning},
+        },
+        {
+            name:       "grouping by day shares the UTC day",
+            groupByDay: true,
+            dates:      []time.Time{utcMidnight, utcMorning},
+            want:       []float64{1.1, 1.1},
+            wantCalls:  1,
+            wantDates:  []time.Time{utcMidnight},
+        },
+        {
+            name:       "grouping by day keeps UTC+2 midnight on the previous day",
+            groupByDay: true,
+            dates:      []time.Time{localMidnight, utcMidnight},
+            want:       []float64{1.0, 1.1},
+            wantCalls:  2,
+            wantDates:  []time.Time{day(2024, 1, 1), utcMidnight},
+        },
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            upstream := &countingConverter{}
+            cache, _ := newTestCache(upstream, time.Hour, 0)
+            cache.GroupByDay = tt.groupByDay
+            for i, date := range tt.dates {
+                got, err := cache.GetRate("EUR", "USD", &date)
+                if err != nil {
+                    t.Fatal(err)
+                }
+                if got != tt.want[i] {
+                    t.Errorf("GetRate(%v) = %v, want %v", date, got, tt.want[i])
+                }
+            }
+            if upstream.calls() != tt.wantCalls {
+                t.Fatalf("upstream calls = %d, want %d", upstream.calls(), tt.wantCalls)
+            }
+            for i, want := range tt.wantDates {
+                if got := upstream.dates[i]; !got.Equal(want) || got.Location() != want.Location() {
+                    t.Errorf("upstream date %d = %v, want %v", i, got, want)
+                }
+            }
+        })
+    }
+}
+
+func TestCachingConverterExpiry(t *testing.T) {
+    tests := []struct {
+        name        string
+        from        string
+        ttl         time.Duration
+        negativeTTL time.Duration
+        advance     time.Duration
+        wantCalls   int
+    }{
+        {"hit within ttl", "EUR", time.Minute, 0, 30 * time.Second, 1},
+        {"miss after ttl", "EUR", time.Minute, 0, time.Minute, 2},
+        {"failure cached within negative ttl", "GBP", time.Minute, 10 * time.Second, 5 * time.Second, 1},
+        {"failure retried after negative ttl", "GBP", time.Minute, 10 * time.Second, 10 * time.Second, 2},
+        {"failure not cached without negative ttl", "GBP", time.Minute, 0, 0, 2},
+        {"nothing cached without ttl", "EUR", 0, 0, 0, 2},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            upstream := &countingConverter{}
+            cache, clock := newTestCache(upstream, tt.ttl, tt.negativeTTL)
+            first, firstErr := cache.GetRate(tt.from, "USD", nil)
+            clock.now = clock.now.Add(tt.advance)
+            second, secondErr := cache.GetRate(tt.from, "USD", nil)
+
+            if upstream.calls() != tt.wantCalls {
+                t.Errorf("upstream calls = %d, want %d", upstream.calls(), tt.wantCalls)
+            }
+            if first != second || (firstErr == nil) != (secondErr == nil) {
+                t.Errorf("lookups disagree: %v, %v and %v, %v", first, firstErr, second, secondErr)
+            }
+            var notFound *RateNotFoundError
+            if tt.from == "GBP" && !errors.As(secondErr, &notFound) {
+                t.Errorf("cached failure = %v, want RateNotFoundError", secondErr)
+            }
+            stats := cache.Stats()
+            if stats.Misses != uint64(tt.wantCalls) || stats.Hits != uint64(2-tt.wantCalls) {
+                t.Errorf("Stats() = %+v", stats)
+            }
+        })
+    }
+}
+
+func TestCachingConverterEviction(t *testing.T) {
+    upstream := &countingConverter{}
+    cache, clock := newTestCache(upstream, time.Minute, 0)
+    cache.MaxEntries = 2
+
+    dates := []time.Time{day(2024, 1, 1), day(2024, 1, 2), day(2024, 1, 3)}
+    for i := range dates {
+        if _, err := cache.GetRate("EUR", "USD", &dates[i]); err != nil {
+            t.Fatal(err)
+        }
+    }
+    if entries := cache.Stats().Entries; entries != 2 {
+        t.Errorf("Entries = %d after exceeding MaxEntries, want 2", entries)
+    }
+    cache.GetRate("EUR", "USD", &dates[2])
+    cache.GetRate("EUR", "USD", &dates[0])
+    if calls := upstream.calls(); calls != 4 {
+        t.Errorf("upstream calls = %d, want the oldest entry evicted and fetched again", calls)
+    }
+
+    clock.now = clock.now.Add(2 * time.Minute)
+    cache.GetRate("EUR", "USD", nil)
+    if entries := cache.Stats().Entries; entries != 1 {
+        t.Errorf("Entries = %d after expiry, want only the new entry", entries)
+    }
+
+    cache.Purge()
+    if entries := cache.Stats().Entries; entries != 0 {
+        t.Errorf("Entries = %d after Purge, want 0", entries)
+    }
+    cache.GetRate("EUR", "USD", nil)
+    if calls := upstream.calls(); calls != 6 {
+        t.Errorf("upstream calls = %d after Purge, want a fresh lookup", calls)
+    }
+}
+
+func TestCachingConverterCoalescing(t *testing.T) {
+    const callers = 8
+    upstream := &countingConverter{release: make(chan struct{})}
+    cache, _ := newTestCache(upstream, time.Minute, 0)
+
+    var wg sync.WaitGroup
+    results := make([]float64, callers)
+    for i := 0; i < callers; i++ {
+        wg.Add(1)
+        go func(i int) {
+            defer wg.Done()
+            results[i], _ = cache.GetRate("EUR", "USD", nil)
+        }(i)
+    }
+
+    deadline := time.Now().Add(5 * time.Second)
+    for cache.Stats().Coalesced < callers-1 {
+        if time.Now().After(deadline) {
+            t.Fatalf("Stats() = %+v, want %d coalesced lookups", cache.Stats(), callers-1)
+        }
+        time.Sleep(time.Millisecond)
+    }
+    close(upstream.release)
+    wg.Wait()
+
+    if calls := upstream.calls(); calls != 1 {
+        t.Errorf("upstream calls = %d, want 1", calls)
+    }
+    for i, rate := range results {
+        if rate != 1.1 {
+            t.Errorf("caller %d got %v, want 1.1", i, rate)
+        }
+    }
+}
+
+// panickingConverter is a CurrencyConverter whose lookups panic
+type panickingConverter struct{}
+
+func (panickingConverter) GetRate(from, to string, date *time.Time) (float64, error) {
+    panic("upstream failure")
+}
+
+func TestCachingConverterUpstreamPanic(t *testing.T) {
+    cache, _ := newTestCache(panickingConverter{}, time.Minute, time.Minute)
+    func() {
+        defer func() {
+            if recover() == nil {
+                t.Error("panic from the wrapped converter was not propagated")
+            }
+        }()
+        cache.GetRate("EUR", "USD", nil)
+    }()
+
+    var validation *ValidationError
+    if _, err := cache.GetRate("EUR", "USD", nil); !errors.As(err, &validation) {
+        t.Errorf("GetRate() after a panic error = %v, want the cached ValidationError", err)
+    }
+}
+
+func TestCachingConverterZeroValue(t *testing.T) {
+    saved := DefaultConverter
+    defer func() { DefaultConverter = saved }()
+
+    upstream := &countingConverter{}
+    DefaultConverter = upstream
+    var cache CachingConverter
+    for i := 0; i < 2; i++ {
+        if rate, err := cache.GetRate("EUR", "USD", nil); err != nil || rate != 1.1 {
+            t.Fatalf("GetRate() on the zero value = %v, %v", rate, err)
+        }
+    }
+    if calls := upstream.calls(); calls != 2 {
+        t.Errorf("upstream calls = %d, want the zero value to cache nothing", calls)
+    }
+    if name := cache.ProviderName(); name != "*money.countingConverter" {
+        t.Errorf("ProviderName() = %q", name)
+    }
+
+    DefaultConverter = nil
+    var validation *ValidationError
+    if _, err := cache.GetRate("EUR", "USD", nil); !errors.As(err, &validation) || validation.Field != "converter" {
+        t.Errorf("GetRate() without a converter error = %v, want converter ValidationError", err)
+    }
+    cache.Purge()
+    if stats := cache.Stats(); stats.Misses != 3 || stats.Entries != 0 {
+        t.Errorf("Stats() = %+v", stats)
+    }
+}
@@ -0,0 +1,293 @@
+package money
+
+import (
+    "strings"
+    "sync"
+    "time"
+)
+
+// HolidayRule returns the holidays a rule produces in a year. Only the calendar date
+// of each returned time is used.
+type HolidayRule func(year int) []time.Time
+
+// FixedHoliday returns a rule for a holiday on the same month and day every year
+func FixedHoliday(month time.Month, day int) HolidayRule {
+    return func(year int) []time.Time {
+        return []time.Time{civilDate(year, month, day)}
+    }
+}
+
+// EasterHoliday returns a rule for a holiday a number of days from Western Easter Sunday,
+// such as -2 for Good Friday or 1 for Easter Monday
+func EasterHoliday(offset int) HolidayRule {
+    return func(year int) []time.Time {
+        return []time.Time{easterSunday(year).AddDate(0, 0, offset)}
+    }
+}
+
+// NthWeekdayHoliday returns a rule for the nth given weekday of a month, such as the
+// third Monday of January. Negative values of n count from the end of the month, so
+// -1 is the last such weekday.
+func NthWeekdayHoliday(month time.Month, weekday time.Weekday, n int) HolidayRule {
+    return func(year int) []time.Time {
+        if n > 0 {
+            first := civilDate(year, month, 1)
+            offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+            return []time.Time{first.AddDate(0, 0, offset+7*(n-1))}
+        }
+        last := civilDate(year, month+1, 0)
+        offset := (int(last.Weekday()) - int(weekday) + 7) % 7
+        return []time.Time{last.AddDate(0, 0, -offset+7*(n+1))}
+    }
+}
+
+// ObservedOnMonday adjusts a rule so that holidays falling on a Sunday are observed
+// on the following Monday
+func ObservedOnMonday(rule HolidayRule) HolidayRule {
+    return func(year int) []time.Time {
+        dates := rule(year)
+        for i, date := range dates {
+            if date.Weekday() == time.Sunday {
+                dates[i] = date.AddDate(0, 0, 1)
+            }
+        }
+        return dates
+    }
+}
+
+// HolidaySince restricts a rule to the years from firstYear onwards
+func HolidaySince(firstYear int, rule HolidayRule) HolidayRule {
+    return func(year int) []time.Time {
+        if year < firstYear {
+            return nil
+        }
+        return rule(year)
+    }
+}
+
+// Calendar defines the business days of a market or settlement system.
+// The holidays of each year are computed on first use and cached; a Calendar
+// must not be modified once it is in use, and is then safe for concurrent use.
+type Calendar struct {
+    Name     string
+    Weekend  []time.Weekday
+    Holidays []HolidayRule
+
+    mu    sync.Mutex
+    years map[int]map[int]bool // Holiday date keys per year
+}
+
+// NewCalendar creates a Calendar with a Saturday and Sunday weekend
+func NewCalendar(name string, holidays ...HolidayRule) *Calendar {
+    return &Calendar{
+        Name:     name,
+        Weekend:  []time.Weekday{time.Saturday, time.Sunday},
+        Holidays: holidays,
+    }
+}
+
+// JointCalendar combines calendars so that a day is a business day only if it is one
+// in every calendar, as needed to settle a currency pair
+func JointCalendar(calendars ...*Calendar) *Calendar {
+    joint := &Calendar{}
+    names := make([]string, 0, len(calendars))
+    seen := make(map[time.Weekday]bool)
+    for _, calendar := range calendars {
+        names = append(names, calendar.Name)
+        for _, day := range calendar.Weekend {
+            if !seen[day] {
+                seen[day] = true
+                joint.Weekend = append(joint.Weekend, day)
+            }
+        }
+        joint.Holidays = append(joint.Holidays, calendar.Holidays...)
+    }
+    joint.Name = strings.Join(names, "+")
+    return joint
+}
+
+// IsHoliday reports whether date falls on one of the calendar's holidays
+func (c *Calendar) IsHoliday(date time.Time) bool {
+    year, month, day := date.Date()
+    key := year*10000 + int(month)*100 + day
+
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    if c.years == nil {
+        c.years = make(map[int]map[int]bool)
+    }
+    holidays, ok := c.years[year]
+    if !ok {
+        holidays = make(map[int]bool)
+        for _, rule := range c.Holidays {
+            for _, holiday := range rule(year) {
+                y, m, d := holiday.Date()
+                holidays[y*10000+int(m)*100+d] = true
+            }
+        }
+        c.years[year] = holidays
+    }
+    return holidays[key]
+}
+
+// IsWeekend reports whether date falls on one of the calendar's weekend days
+func (c *Calendar) IsWeekend(date time.Time) bool {
+    for _, day := range c.Weekend {
+        if date.Weekday() == day {
+            return true
+        }
+    }
+    return false
+}
+
+// IsBusinessDay reports whether date is neither a weekend day nor a holiday
+func (c *Calendar) IsBusinessDay(date time.Time) bool {
+    return !c.IsWeekend(date) && !c.IsHoliday(date)
+}
+
+// NextBusinessDay returns the first business day after date
+func (c *Calendar) NextBusinessDay(date time.Time) time.Time {
+    return c.AddBusinessDays(date, 1)
+}
+
+// PreviousBusinessDay returns the last business day before date
+func (c *Calendar) PreviousBusinessDay(date time.Time) time.Time {
+    return c.AddBusinessDays(date, -1)
+}
+
+// AddBusinessDays moves date by n business days, backwards when n is negative.
+// The time of day is preserved.
+func (c *Calendar) AddBusinessDays(date time.Time, n int) time.Time {
+    step := 1
+    if n < 0 {
+        step, n = -1, -n
+    }
+    for n > 0 {
+        date = date.AddDate(0, 0, step)
+        if c.IsBusinessDay(date) {
+            n--
+        }
+    }
+    return date
+}
+
+// BusinessDaysBetween returns the number of business days after start up to and
+// including end, or the negated count when end is before start
+func (c *Calendar) BusinessDaysBetween(start, end time.Time) int {
+    startDay, endDay := truncateDay(start), truncateDay(end.In(start.Location()))
+    sign := 1
+    if endDay.Before(startDay) {
+        startDay, endDay, sign = endDay, startDay, -1
+    }
+    count := 0
+    for day := startDay.AddDate(0, 0, 1); !day.After(endDay); day = day.AddDate(0, 0, 1) {
+        if c.IsBusinessDay(day) {
+            count++
+        }
+    }
+    return sign * count
+}
+
+// businessDaysWithin reports whether end is no more than limit business days after
+// start, counting at most limit+1 days so that distant dates are rejected quickly
+func (c *Calendar) businessDaysWithin(start, end time.Time, limit int) bool {
+    endDay := truncateDay(end.In(start.Location()))
+    count := 0
+    for day := truncateDay(start).AddDate(0, 0, 1); !day.After(endDay); day = day.AddDate(0, 0, 1) {
+        if c.IsBusinessDay(day) {
+            if count++; count > limit {
+                return false
+            }
+        }
+    }
+    return true
+}
+
+// SettlementDate returns the date a trade settles after lag business days, as in
+// T+1 or T+2. A trade date that is not a business day is first rolled forward.
+func (c *Calendar) SettlementDate(tradeDate time.Time, lag int) time.Time {
+    if !c.IsBusinessDay(tradeDate) {
+        tradeDate = c.NextBusinessDay(tradeDate)
+    }
+    return c.AddBusinessDays(tradeDate, lag)
+}
+
+// TARGET2 is the calendar of the euro area's TARGET2 settlement system
+var TARGET2 = NewCalendar("TARGET2",
+    FixedHoliday(time.January, 1),
+    EasterHoliday(-2), // Good Friday
+    EasterHoliday(1),  // Easter Monday
+    FixedHoliday(time.May, 1),
+    FixedHoliday(time.December, 25),
+    FixedHoliday(time.December, 26),
+)
+
+// USFederalReserve is the calendar of the US Federal Reserve banks. Holidays falling on
+// a Sunday are observed on the Monday; those falling on a Saturday are not moved.
+var USFederalReserve = NewCalendar("USFederalReserve",
+    ObservedOnMonday(FixedHoliday(time.January, 1)),
+    NthWeekdayHoliday(time.January, time.Monday, 3),  // Martin Luther King Jr. Day
+    NthWeekdayHoliday(time.February, time.Monday, 3), // Washington's Birthday
+    NthWeekdayHoliday(time.May, time.Monday, -1),     // Memorial Day
+    HolidaySince(2022, ObservedOnMonday(FixedHoliday(time.June, 19))),
+    ObservedOnMonday(FixedHoliday(time.July, 4)),
+    NthWeekdayHoliday(time.September, time.Monday, 1), // Labor Day
+    NthWeekdayHoliday(time.October, time.Monday, 2),   // Columbus Day
+    ObservedOnMonday(FixedHoliday(time.November, 11)),
+    NthWeekdayHoliday(time.November, time.Thursday, 4), // Thanksgiving Day
+    ObservedOnMonday(FixedHoliday(time.December, 25)),
+)
+
+// CurrencyCalendars maps currency codes to the calendar of their settlement system
+var CurrencyCalendars = map[string]*Calendar{
+    "EUR": TARGET2,
+    "USD": USFederalReserve,
+}
+
+// PairCalendar returns the joint calendar of the currencies in a pair, ignoring
+// currencies without an entry in CurrencyCalendars. It returns nil if neither has one.
+func PairCalendar(from, to string) *Calendar {
+    var calendars []*Calendar
+    for _, code := range []string{from, to} {
+        if calendar, ok := CurrencyCalendars[code]; ok {
+            calendars = append(calendars, calendar)
+        }
+    }
+    switch len(calendars) {
+    case 0:
+        return nil
+    case 1:
+        return calendars[0]
+    }
+    if calendars[0] == calendars[1] {
+        return calendars[0]
+    }
+    return JointCalendar(calendars...)
+}
+
+// civilDate returns midnight UTC of a calendar date, normalizing out-of-range days
+func civilDate(year int, month time.Month, day int) time.Time {
+    return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// truncateDay returns midnight of date's calendar day in its own location
+func truncateDay(date time.Time) time.Time {
+    year, month, day := date.Date()
+    return time.Date(year, month, day, 0, 0, 0, 0, date.Location())
+}
+
+// easterSunday returns Western Easter Sunday using the anonymous Gregorian algorithm
+func easterSunday(year int) time.Time {
+    a := year % 19
+    b, c := year/100, year%100
+    d, e := b/4, b%4
+    f := (b + 8) / 25
+    g := (b - f + 1) / 3
+    h := (19*a + b - d - g + 15) % 30
+    i, k := c/4, c%4
+    l := (32 + 2*e + 2*i - h - k) % 7
+    m := (a + 11*h + 22*l) / 451
+    month := (h + l - 7*m + 114) / 31
+    day := (h+l-7*m+114)%31 + 1
+    return civilDate(year, time.Month(month), day)
+}
@@ -0,0 +1,222 @@
+package money
+
+import (
+    "testing"
+    "time"
+)
+
+func TestHolidayRules(t *testing.T) {
+    tests := []struct {
+        name string
+        rule HolidayRule
+        year int
+        want time.Time
+    }{
+        {"fixed", FixedHoliday(time.May, 1), 2024, day(2024, 5, 1)},
+        {"good friday", EasterHoliday(-2), 2024, day(2024, 3, 29)},
+        {"easter monday", EasterHoliday(1), 2025, day(2025, 4, 21)},
+        {"easter in march", EasterHoliday(0), 2008, day(2008, 3, 23)},
+        {"late easter", EasterHoliday(0), 2038, day(2038, 4, 25)},
+        {"third monday", NthWeekdayHoliday(time.January, time.Monday, 3), 2024, day(2024, 1, 15)},
+        {"first monday on the first", NthWeekdayHoliday(time.April, time.Monday, 1), 2024, day(2024, 4, 1)},
+        {"fourth thursday", NthWeekdayHoliday(time.November, time.Thursday, 4), 2024, day(2024, 11, 28)},
+        {"last monday", NthWeekdayHoliday(time.May, time.Monday, -1), 2024, day(2024, 5, 27)},
+        {"last monday on the last", NthWeekdayHoliday(time.May, time.Monday, -1), 2021, day(2021, 5, 31)},
+        {"second to last friday", NthWeekdayHoliday(time.December, time.Friday, -2), 2024, day(2024, 12, 20)},
+        {"last day of december", NthWeekdayHoliday(time.December, time.Tuesday, -1), 2024, day(2024, 12, 31)},
+        {"sunday observed on monday", ObservedOnMonday(FixedHoliday(time.July, 4)), 2021, day(2021, 7, 5)},
+        {"saturday not moved", ObservedOnMonday(FixedHoliday(time.July, 4)), 2020, day(2020, 7, 4)},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got := tt.rule(tt.year)
+            if len(got) != 1 || !got[0].Equal(tt.want) {
+                t.Errorf("rule(%d) = %v, want %v", tt.year, got, tt.want)
+            }
+        })
+    }
+
+    since := HolidaySince(2022, FixedHoliday(time.June, 19))
+    if got := since(2021); len(got) != 0 {
+        t.Errorf("HolidaySince(2022) in 2021 = %v, want none", got)
+    }
+    if got := since(2022); len(got) != 1 {
+        t.Errorf("HolidaySince(2022) in 2022 = %v, want one holiday", got)
+    }
+}
+
+func TestCalendarBusinessDays(t *testing.T) {
+    tests := []struct {
+        name     string
+        calendar *Calendar
+        date     time.Time
+        want     bool
+    }{
+        {"TARGET2 weekday", TARGET2, day(2024, 3, 28), true},
+        {"TARGET2 good friday", TARGET2, day(2024, 3, 29), false},
+        {"TARGET2 easter monday", TARGET2, day(2024, 4, 1), false},
+        {"TARGET2 labour day", TARGET2, day(2024, 5, 1), false},
+        {"TARGET2 boxing day", TARGET2, day(2024, 12, 26), false},
+        {"TARGET2 saturday", TARGET2, day(2024, 3, 30), false},
+        {"TARGET2 US independence day", TARGET2, day(2024, 7, 4), true},
+        {"Fed independence day", USFederalReserve, day(2024, 7, 4), false},
+        {"Fed observed independence day", USFederalReserve, day(2021, 7, 5), false},
+        {"Fed friday before saturday holiday", USFederalReserve, day(2020, 7, 3), true},
+        {"Fed juneteenth before 2022", USFederalReserve, day(2020, 6, 19), true},
+        {"Fed observed juneteenth", USFederalReserve, day(2022, 6, 20), false},
+        {"Fed juneteenth", USFederalReserve, day(2023, 6, 19), false},
+        {"Fed thanksgiving", USFederalReserve, day(2024, 11, 28), false},
+        {"Fed day after thanksgiving", USFederalReserve, day(2024, 11, 29), true},
+        {"Fed observed christmas", USFederalReserve, day(2022, 12, 26), false},
+        {"Fed good friday", USFederalReserve, day(2024, 3, 29), true},
+        {"Fed late evening in another zone", USFederalReserve, time.Date(2024, 7, 4, 23, 0, 0, 0, time.FixedZone("UTC-5", -5*3600)), false},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := tt.calendar.IsBusinessDay(tt.date); got != tt.want {
+                t.Errorf("IsBusinessDay(%s) = %v, want %v", tt.date.Format("2006-01-02"), got, tt.want)
+            }
+        })
+    }
+}
+
+func TestCalendarArithmetic(t *testing.T) {
+    tests := []struct {
+        name string
+        got  time.Time
+        want time.Time
+    }{
+        {"next over easter", TARGET2.NextBusinessDay(day(2024, 3, 28)), day(2024, 4, 2)},
+        {"previous over easter", TARGET2.PreviousBusinessDay(day(2024, 4, 2)), day(2024, 3, 28)},
+        {"add over a weekend", TARGET2.AddBusinessDays(day(2024, 3, 22), 3), day(2024, 3, 27)},
+        {"subtract", TARGET2.AddBusinessDays(day(2024, 4, 3), -3), day(2024, 3, 27)},
+        {"add zero", TARGET2.AddBusinessDays(day(2024, 3, 30), 0), day(2024, 3, 30)},
+        {"keeps time of day", TARGET2.AddBusinessDays(day(2024, 3, 28).Add(15*time.Hour), 1), day(2024, 4, 2).Add(15 * time.Hour)},
+        {"T+2 over easter", TARGET2.SettlementDate(day(2024, 3, 28), 2), day(2024, 4, 3)},
+        {"T+2 from a saturday", TARGET2.SettlementDate(day(2024, 3, 23), 2), day(2024, 3, 27)},
+        {"T+1 before thanksgiving", USFederalReserve.SettlementDate(day(2024, 11, 27), 1), day(2024, 11, 29)},
+        {"T+0 on a holiday rolls forward", USFederalReserve.SettlementDate(day(2024, 7, 4), 0), day(2024, 7, 5)},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if !tt.got.Equal(tt.want) {
+                t.Errorf("got %v, want %v", tt.got, tt.want)
+            }
+        })
+    }
+}
+
+func TestBusinessDaysBetween(t *testing.T) {
+    tests := []struct {
+        name       string
+        start, end time.Time
+        want       int
+    }{
+        {"over easter", day(2024, 3, 22), day(2024, 4, 3), 6},
+        {"reversed", day(2024, 4, 3), day(2024, 3, 22), -6},
+        {"same day", day(2024, 3, 22), day(2024, 3, 22).Add(10 * time.Hour), 0},
+        {"friday to monday", day(2024, 3, 22), day(2024, 3, 25), 1},
+        {"to a holiday", day(2024, 3, 28), day(2024, 3, 29), 0},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := TARGET2.BusinessDaysBetween(tt.start, tt.end); got != tt.want {
+                t.Errorf("BusinessDaysBetween() = %d, want %d", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestBusinessDaysWithin(t *testing.T) {
+    tests := []struct {
+        name       string
+        start, end time.Time
+        limit      int
+        want       bool
+    }{
+        {"at the limit", day(2024, 3, 22), day(2024, 4, 3), 6, true},
+        {"one over the limit", day(2024, 3, 22), day(2024, 4, 3), 5, false},
+        {"same day", day(2024, 3, 22), day(2024, 3, 22), 0, true},
+        {"end before start", day(2024, 4, 3), day(2024, 3, 22), 0, true},
+        {"decades apart", day(1990, 1, 1), day(2090, 1, 1), 10, false},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := TARGET2.businessDaysWithin(tt.start, tt.end, tt.limit); got != tt.want {
+                t.Errorf("businessDaysWithin(%d) = %v, want %v", tt.limit, got, tt.want)
+            }
+        })
+    }
+}
+
+func TestPairCalendar(t *testing.T) {
+    tests := []struct {
+        from, to string
+        want     string // Empty for no calendar
+    }{
+        {"EUR", "USD", "TARGET2+USFederalReserve"},
+        {"USD", "EUR", "USFederalReserve+TARGET2"},
+        {"EUR", "EUR", "TARGET2"},
+        {"GBP", "EUR", "TARGET2"},
+        {"GBP", "JPY", ""},
+    }
+    for _, tt := range tests {
+        t.Run(tt.from+"/"+tt.to, func(t *testing.T) {
+            calendar := PairCalendar(tt.from, tt.to)
+            if calendar == nil {
+                if tt.want != "" {
+                    t.Errorf("PairCalendar() = nil, want %s", tt.want)
+                }
+                return
+            }
+            if calendar.Name != tt.want {
+                t.Errorf("PairCalendar() = %s, want %s", calendar.Name, tt.want)
+            }
+        })
+    }
+
+    joint := PairCalendar("EUR", "USD")
+    for _, date := range []time.Time{day(2024, 5, 1), day(2024, 7, 4), day(2024, 3, 30)} {
+        if joint.IsBusinessDay(date) {
+            t.Errorf("joint calendar treats %s as a business day", date.Format("2006-01-02"))
+        }
+    }
+}
+
+func TestRateTableCalendarFallback(t *testing.T) {
+    tests := []struct {
+        name       string
+        resolution DateResolution
+        lookback   int
+        date       time.Time
+        want       string // Empty when no rate should be found
+    }{
+        {"exact on good friday uses thursday", ResolveExact, 0, day(2024, 3, 29), "1.08"},
+        {"exact on easter sunday uses thursday", ResolveExact, 0, day(2024, 3, 31), "1.08"},
+        {"exact on a business day without a rate", ResolveExact, 0, day(2024, 4, 3), ""},
+        {"lookback over easter", ResolvePrevious, 1, day(2024, 4, 2), "1.08"},
+        {"lookback exceeded", ResolvePrevious, 1, day(2024, 4, 3), ""},
+        {"lookback exceeded long after", ResolvePrevious, 5, day(2034, 4, 3), ""},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            table := &RateTable{Resolution: tt.resolution, Calendar: TARGET2, MaxLookbackDays: tt.lookback}
+            if err := table.SetRate("EUR", "USD", MustParseRate("1.08"), day(2024, 3, 28)); err != nil {
+                t.Fatal(err)
+            }
+            rate, err := table.GetExactRate("EUR", "USD", &tt.date)
+            if tt.want == "" {
+                if err == nil {
+                    t.Errorf("GetExactRate() = %v, want no rate", rate)
+                }
+                return
+            }
+            if err != nil {
+                t.Fatal(err)
+            }
+            if rate.Cmp(MustParseRate(tt.want)) != 0 {
+                t.Errorf("GetExactRate() = %v, want %s", rate, tt.want)
+            }
+        })
+    }
+}
@@ -0,0 +1,139 @@
+package money
+
+import (
+    "fmt"
+    "math"
+    "math/big"
+    "time"
+)
+
+// ChainPolicy selects how a ChainConverter combines the answers of its providers
+type ChainPolicy int
+
+const (
+    ChainFirstHit  ChainPolicy = iota // Use the first provider that supplies a rate
+    ChainAgreement                    // Query every provider and require the answers to agree
+)
+
+// ChainLink is a named provider in a ChainConverter
+type ChainLink struct {
+    Name      string // Reported in audit records and errors; defaults to the converter's name
+    Converter CurrencyConverter
+}
+
+// ChainConverter tries several converters in order, e.g. a primary rate source,
+// a secondary source and a last-resort static RateTable. The provider that answered
+// is reported through GetRateInfo so conversion audit records include it.
+type ChainConverter struct {
+    Links  []ChainLink
+    Policy ChainPolicy
+
+    // Tolerance is the maximum relative difference from the first answer allowed
+    // under ChainAgreement, e.g. 0.001 for 0.1%
+    Tolerance float64
+
+    // Quorum is the minimum number of providers that must answer under ChainAgreement;
+    // values below 2 mean 2
+    Quorum int
+}
+
+// NewChainConverter creates a ChainConverter that uses the first provider to answer
+func NewChainConverter(links ...ChainLink) *ChainConverter {
+    return &ChainConverter{Links: links, Policy: ChainFirstHit}
+}
+
+// GetRate implements CurrencyConverter
+func (c *ChainConverter) GetRate(from, to string, date *time.Time) (float64, error) {
+    info, err := c.GetRateInfo(from, to, date)
+    if err != nil {
+        return 0, err
+    }
+    return info.Rate.Float64(), nil
+}
+
+// GetExactRate implements RateProvider
+func (c *ChainConverter) GetExactRate(from, to string, date *time.Time) (Rate, error) {
+    info, err := c.GetRateInfo(from, to, date)
+    if err != nil {
+        return Rate{}, err
+    }
+    return info.Rate, nil
+}
+
+// GetRateInfo implements RateInfoProvider. The Provider field names the link that answered.
+// If no acceptable rate is found, the error is a *ChainError listing every failure.
+func (c *ChainConverter) GetRateInfo(from, to string, date *time.Time) (RateInfo, error) {
+    chainErr := &ChainError{From: from, To: to}
+    var answers []RateInfo
+
+    for _, link := range c.Links {
+        name := link.Name
+        if name == "" {
+            name = providerName(link.Converter)
+        }
+
+        info, err := lookupRateInfo(ProviderFromConverter(link.Converter), from, to, date)
+        if err == nil && !info.Rate.IsPositive() {
+            err = &ValidationError{
+                Field:   "rate",
+                Message: fmt.Sprintf("non-positive rate %s", info.Rate),
+            }
+        }
+        if err != nil {
+            chainErr.Failures = append(chainErr.Failures, ProviderFailure{Provider: name, Err: err})
+            continue
+        }
+
+        info.Provider = name
+        if c.Policy == ChainFirstHit {
+            return info, nil
+        }
+        answers = append(answers, info)
+    }
+
+    if c.Policy == ChainFirstHit {
+        chainErr.Reason = "no provider supplied a rate"
+        return RateInfo{}, chainErr
+    }
+
+    quorum := c.Quorum
+    if quorum < 2 {
+        quorum = 2
+    }
+    if len(answers) < quorum {
+        chainErr.Reason = fmt.Sprintf("%d of %d required providers supplied a rate", len(answers), quorum)
+        return RateInfo{}, chainErr
+    }
+
+    reference := answers[0]
+    tolerance := new(big.Rat)
+    if c.Tolerance > 0 && !math.IsInf(c.Tolerance, 0) {
+        tolerance.SetFloat64(c.Tolerance)
+    }
+    disagreement := false
+    for _, answer := range answers[1:] {
+        deviation := new(big.Rat).Sub(answer.Rate.rat(), reference.Rate.rat())
+        deviation.Abs(deviation).Quo(deviation, reference.Rate.rat())
+        if deviation.Cmp(tolerance) > 0 {
+            disagreement = true
+            relative, _ := deviation.Float64()
+            chainErr.Failures = append(chainErr.Failures, ProviderFailure{
+                Provider: answer.Provider,
+                Err: &ValidationError{
+                    Field:   "rate",
+                    Message: fmt.Sprintf("rate %s deviates %.4g%% from %s's %s", answer.Rate, relative*100, reference.Provider, reference.Rate),
+                },
+            })
+        }
+    }
+    if disagreement {
+        chainErr.Reason = "providers disagree beyond tolerance"
+        return RateInfo{}, chainErr
+    }
+    return reference, nil
+}
+
+// ProviderName implements NamedProvider
+func (c *ChainConverter) ProviderName() string {
+    return "ChainConverter"
+}
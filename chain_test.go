@@ -0,0 +1,128 @@
+package money
+
+import (
+    "errors"
+    "testing"
+)
+
+func TestChainConverter(t *testing.T) {
+    primary := mapConverter{{From: "EUR", To: "USD"}: 1.10}
+    secondary := mapConverter{{From: "EUR", To: "USD"}: 1.101, {From: "GBP", To: "USD"}: 1.25}
+    broken := mapConverter{{From: "EUR", To: "USD"}: 0}
+    static := &RateTable{Name: "static"}
+    if err := static.Set("EUR", "USD", 1.2, day(2024, 1, 1)); err != nil {
+        t.Fatal(err)
+    }
+
+    tests := []struct {
+        name         string
+        chain        *ChainConverter
+        from, to     string
+        want         string
+        wantProvider string
+        wantFailures int // Expected ChainError failures when want is empty
+    }{
+        {
+            name:  "first hit",
+            chain: NewChainConverter(ChainLink{Name: "primary", Converter: primary}, ChainLink{Name: "secondary", Converter: secondary}),
+            from:  "EUR", to: "USD", want: "1.1", wantProvider: "primary",
+        },
+        {
+            name:  "falls through",
+            chain: NewChainConverter(ChainLink{Name: "primary", Converter: primary}, ChainLink{Name: "secondary", Converter: secondary}),
+            from:  "GBP", to: "USD", want: "1.25", wantProvider: "secondary",
+        },
+        {
+            name:  "skips non-positive rates",
+            chain: NewChainConverter(ChainLink{Name: "broken", Converter: broken}, ChainLink{Converter: static}),
+            from:  "EUR", to: "USD", want: "1.2", wantProvider: "static",
+        },
+        {
+            name:  "default link name",
+            chain: NewChainConverter(ChainLink{Converter: primary}),
+            from:  "EUR", to: "USD", want: "1.1", wantProvider: "money.mapConverter",
+        },
+        {
+            name:  "no provider answers",
+            chain: NewChainConverter(ChainLink{Name: "primary", Converter: primary}, ChainLink{Name: "broken", Converter: broken}),
+            from:  "GBP", to: "USD", wantFailures: 2,
+        },
+        {
+            name: "agreement within tolerance",
+            chain: &ChainConverter{
+                Links:     []ChainLink{{Name: "primary", Converter: primary}, {Name: "secondary", Converter: secondary}},
+                Policy:    ChainAgreement,
+                Tolerance: 0.001,
+            },
+            from: "EUR", to: "USD", want: "1.1", wantProvider: "primary",
+        },
+        {
+            name: "agreement beyond tolerance",
+            chain: &ChainConverter{
+                Links:     []ChainLink{{Name: "primary", Converter: primary}, {Name: "secondary", Converter: secondary}},
+                Policy:    ChainAgreement,
+                Tolerance: 0.0001,
+            },
+            from: "EUR", to: "USD", wantFailures: 1,
+        },
+        {
+            name: "agreement without quorum",
+            chain: &ChainConverter{
+                Links:     []ChainLink{{Name: "primary", Converter: primary}, {Name: "secondary", Converter: secondary}, {Name: "broken", Converter: broken}},
+                Policy:    ChainAgreement,
+                Tolerance: 0.01,
+                Quorum:    3,
+            },
+            from: "EUR", to: "USD", wantFailures: 1,
+        },
+        {
+            name: "agreement with a single answer",
+            chain: &ChainConverter{
+                Links:  []ChainLink{{Name: "primary", Converter: primary}, {Name: "secondary", Converter: secondary}},
+                Policy: ChainAgreement,
+            },
+            from: "GBP", to: "USD", wantFailures: 1,
+        },
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            info, err := tt.chain.GetRateInfo(tt.from, tt.to, nil)
+            if tt.want == "" {
+                var chainErr *ChainError
+                if !errors.As(err, &chainErr) || len(chainErr.Failures) != tt.wantFailures {
+                    t.Fatalf("GetRateInfo() error = %v, want ChainError with %d failures", err, tt.wantFailures)
+                }
+                return
+            }
+            if err != nil {
+                t.Fatal(err)
+            }
+            if info.Rate.Cmp(MustParseRate(tt.want)) != 0 || info.Provider != tt.wantProvider {
+                t.Errorf("GetRateInfo() = %v from %s, want %s from %s", info.Rate, info.Provider, tt.want, tt.wantProvider)
+            }
+        })
+    }
+}
+
+func TestChainConverterKeepsProvenance(t *testing.T) {
+    static := &RateTable{}
+    if err := static.Set("EUR", "USD", 1.2, day(2024, 1, 1)); err != nil {
+        t.Fatal(err)
+    }
+    chain := NewChainConverter(ChainLink{Name: "static", Converter: static})
+
+    date := day(2024, 3, 1)
+    info, err := chain.GetRateInfo("EUR", "USD", &date)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !info.EffectiveDate.Equal(day(2024, 1, 1)) {
+        t.Errorf("EffectiveDate = %v, want the stored rate's date", info.EffectiveDate)
+    }
+
+    _, err = chain.GetRate("GBP", "USD", nil)
+    var notFound *RateNotFoundError
+    if !errors.As(err, &notFound) {
+        t.Errorf("GetRate() error = %v, want it to unwrap to RateNotFoundError", err)
+    }
+}
@@ -28,8 +28,8 @@ func ValidateMoneySlice(slice MoneySlice) error {
     for _, money := range slice[1:] {
         if money.currency != currency {
             return &CurrencyMismatchError{
-                Currency1: currency.Code,
-                Currency2: money.currency.Code,
+                Currency1: currency.code(),
+                Currency2: money.currency.code(),
             }
         }
     }
@@ -52,20 +52,43 @@ func Sum(slice MoneySlice) (*Money, error) {
         return nil, fmt.Errorf("sum validation failed: %w", err)
     }
 
-    result := &Money{
-        amount:   0,
-        currency: slice[0].currency,
-    }
-
+    // Accumulate in place rather than through Add to avoid an allocation per element
+    var total int64
     for _, money := range slice {
         var err error
-        result, err = result.Add(money)
-        if err != nil {
+        if total, err = addAmounts(total, money.amount); err != nil {
             return nil, fmt.Errorf("sum operation failed: %w", err)
         }
     }
 
-    return result, nil
+    return &Money{amount: total, currency: slice[0].currency}, nil
+}
+
+// SumValues returns the sum of a slice of Money values without allocating
+func SumValues(values []Money) (Money, error) {
+    if len(values) == 0 {
+        return Money{}, &ValidationError{
+            Field:   "slice",
+            Message: "empty slice provided",
+        }
+    }
+
+    currency := values[0].currency
+    var total int64
+    for _, money := range values {
+        if money.currency != currency {
+            return Money{}, &CurrencyMismatchError{
+                Currency1: currency.code(),
+                Currency2: money.currency.code(),
+            }
+        }
+        var err error
+        if total, err = addAmounts(total, money.amount); err != nil {
+            return Money{}, fmt.Errorf("sum operation failed: %w", err)
+        }
+    }
+
+    return Money{amount: total, currency: currency}, nil
 }
 
 // Average returns the arithmetic mean of a slice of Money values
@@ -123,6 +146,17 @@ func Filter(slice MoneySlice, predicate func(*Money) bool) MoneySlice {
     return result
 }
 
+// FilterValues returns a new slice containing only the Money values that satisfy the predicate
+func FilterValues(values []Money, predicate func(Money) bool) []Money {
+    result := make([]Money, 0, len(values))
+    for _, money := range values {
+        if predicate(money) {
+            result = append(result, money)
+        }
+    }
+    return result
+}
+
 // Example predicates that can be used with Filter
 func IsPositivePredicate(m *Money) bool { return m.IsPositive() }
 func IsNegativePredicate(m *Money) bool { return m.IsNegative() }
@@ -148,3 +182,50 @@ func Map(slice MoneySlice, transform func(*Money) *Money) (MoneySlice, error) {
     }
     return result, nil
 }
+
+// MapValues applies a transformation function to each Money value in the slice
+// Note: The transformation must maintain the same currency
+func MapValues(values []Money, transform func(Money) Money) ([]Money, error) {
+    result := make([]Money, len(values))
+    for i, money := range values {
+        transformed := transform(money)
+        if transformed.currency != money.currency {
+            return nil, &ValidationError{
+                Field:   "transform",
+                Message: "transformation must maintain the same currency",
+            }
+        }
+        result[i] = transformed
+    }
+    return result, nil
+}
+
+// FormatSlice formats each Money value with its currency's default options.
+// Symbols are chosen with SymbolAuto for the given home currency, and any
+// currencies in the slice that share a standard symbol with each other are
+// always shown in their international form so the output stays readable.
+func FormatSlice(slice MoneySlice, homeCurrency string) []string {
+    bySymbol := make(map[string]string)
+    clashing := make(map[string]bool)
+    for _, money := range slice {
+        currency := money.currency.details()
+        code, seen := bySymbol[currency.Symbol]
+        if !seen {
+            bySymbol[currency.Symbol] = currency.Code
+        } else if code != currency.Code {
+            clashing[currency.Symbol] = true
+        }
+    }
+
+    result := make([]string, len(slice))
+    for i, money := range slice {
+        opts := money.defaultFormatOptions()
+        opts.SymbolStyle = SymbolAuto
+        opts.HomeCurrency = homeCurrency
+        if clashing[money.currency.details().Symbol] {
+            opts.SymbolStyle = SymbolInternational
+        }
+        result[i] = money.FormatWithOptions(opts)
+    }
+    return result
+}
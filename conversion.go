@@ -0,0 +1,265 @@
+package money
+
+import (
+    "fmt"
+    "math/big"
+    "time"
+)
+
+// RateInfo describes an exchange rate together with where it came from
+type RateInfo struct {
+    From          string    `json:"from"`
+    To            string    `json:"to"`
+    Rate          Rate      `json:"rate"`
+    Provider      string    `json:"provider,omitempty"`
+    EffectiveDate time.Time `json:"effective_date"` // Zero when the provider does not report one
+}
+
+// RateInfoProvider is implemented by rate providers that can report the provenance
+// of a rate, such as its effective date and the source that supplied it
+type RateInfoProvider interface {
+    GetRateInfo(from, to string, date *time.Time) (RateInfo, error)
+}
+
+// NamedProvider is implemented by rate sources that report a name for audit records
+type NamedProvider interface {
+    ProviderName() string
+}
+
+// providerName returns the name reported by a rate source, or its type name
+func providerName(source interface{}) string {
+    if named, ok := source.(NamedProvider); ok {
+        return named.ProviderName()
+    }
+    return fmt.Sprintf("%T", source)
+}
+
+// lookupRateInfo fetches a rate with as much provenance as the provider can report
+func lookupRateInfo(provider RateProvider, from, to string, date *time.Time) (RateInfo, error) {
+    if infoProvider, ok := provider.(RateInfoProvider); ok {
+        return infoProvider.GetRateInfo(from, to, date)
+    }
+    rate, err := provider.GetExactRate(from, to, date)
+    if err != nil {
+        return RateInfo{}, err
+    }
+    return RateInfo{From: from, To: to, Rate: rate, Provider: providerName(provider)}, nil
+}
+
+// ConversionLeg records one step of a conversion and the rounded amount it produced.
+// Whether intermediate amounts feed the next step depends on the conversion; see
+// the method that produced the record.
+type ConversionLeg struct {
+    RateInfo
+    Amount *Money `json:"amount"`
+}
+
+// ConversionResult is an audit record of a conversion: the amounts involved, the
+// rates used and their provenance, and the effect of rounding. It is JSON-serializable
+// so it can be stored alongside the transaction.
+type ConversionResult struct {
+    Source   *Money          `json:"source"`
+    Target   *Money          `json:"target"`
+    Rate     Rate            `json:"rate"` // Overall rate applied, the product of the leg rates
+    Legs     []ConversionLeg `json:"legs"`
+    Date     *time.Time      `json:"date,omitempty"` // Requested rate date, nil for latest
+    Rounding RoundingMethod  `json:"rounding"`
+    Residue  Rate            `json:"residue"` // Exact minus rounded target amount, in target minor units
+}
+
+// ConvertWithResult converts Money with an exact rate supplied by the caller and
+// returns an audit record of the conversion
+func (m *Money) ConvertWithResult(targetCurrency string, rate Rate) (*ConversionResult, error) {
+    return m.convertWithLegs(targetCurrency, nil, []RateInfo{{
+        From:     m.currency.code(),
+        To:       targetCurrency,
+        Rate:     rate,
+        Provider: "manual",
+    }})
+}
+
+// ConvertWithProvider converts Money with a rate from provider (DefaultRateProvider
+// or DefaultConverter when nil) and returns an audit record of the conversion
+func (m *Money) ConvertWithProvider(provider RateProvider, targetCurrency string, date *time.Time) (*ConversionResult, error) {
+    if provider == nil {
+        provider = defaultRateProvider()
+    }
+    if provider == nil {
+        return nil, &ValidationError{
+            Field:   "converter",
+            Message: "no rate provider or currency converter configured",
+        }
+    }
+
+    info, err := lookupRateInfo(provider, m.currency.code(), targetCurrency, date)
+    if err != nil {
+        return nil, &ValidationError{
+            Field:   "exchange_rate",
+            Message: fmt.Sprintf("could not fetch rate from %s to %s: %v", m.currency.code(), targetCurrency, err),
+        }
+    }
+    return m.convertWithLegs(targetCurrency, date, []RateInfo{info})
+}
+
+// ConvertViaReferenceWithResult converts through a reference currency exactly as
+// ConvertViaReference does, using converter (DefaultConverter when nil), and returns
+// an audit record of it. The amount is converted to the reference currency and rounded,
+// and that rounded reference amount is converted to the target and rounded again.
+// When converter implements RateInfoProvider the legs report each rate's provenance;
+// otherwise each leg's Rate is the float64 rate at its shortest decimal representation.
+// Residue is that of the final step, from the float64 arithmetic that produced the target.
+func (m *Money) ConvertViaReferenceWithResult(converter CurrencyConverter, targetCurrency, referenceCurrency string, date *time.Time) (*ConversionResult, error) {
+    if converter == nil {
+        converter = DefaultConverter
+    }
+    if converter == nil {
+        return nil, &ValidationError{
+            Field:   "converter",
+            Message: "no currency converter configured",
+        }
+    }
+
+    toReference, toReferenceRate, err := lookupFloatRate(converter, m.currency.code(), referenceCurrency, date)
+    if err != nil {
+        return nil, err
+    }
+
+    referenceCurrencyObj, err := GetCurrency(referenceCurrency)
+    if err != nil {
+        return nil, err
+    }
+
+    referenceAmount := convertAmount(m.amount, toReferenceRate, m.currency.details(), referenceCurrencyObj)
+    fromReference, fromReferenceRate, err := lookupFloatRate(converter, referenceCurrency, targetCurrency, date)
+    if err != nil {
+        return nil, err
+    }
+
+    targetCurrencyObj, err := GetCurrency(targetCurrency)
+    if err != nil {
+        return nil, err
+    }
+
+    targetAmount := convertAmount(referenceAmount, fromReferenceRate, referenceCurrencyObj, targetCurrencyObj)
+
+    // Apply Brazilian rounding if converting to BRL
+    if targetCurrencyObj.Code == "BRL" {
+        targetAmount = formatBrazilianAmount(targetAmount)
+    }
+
+    residue, err := RateFromFloat(scaledAmount(referenceAmount, fromReferenceRate, referenceCurrencyObj, targetCurrencyObj) - float64(targetAmount))
+    if err != nil {
+        return nil, err
+    }
+
+    reference := &Money{amount: referenceAmount, currency: internCurrency(referenceCurrencyObj.Code)}
+    target := &Money{amount: targetAmount, currency: internCurrency(targetCurrencyObj.Code)}
+    source := *m
+    return &ConversionResult{
+        Source: &source,
+        Target: target,
+        Rate:   toReference.Rate.Mul(fromReference.Rate),
+        Legs: []ConversionLeg{
+            {RateInfo: toReference, Amount: reference},
+            {RateInfo: fromReference, Amount: target},
+        },
+        Date:     date,
+        Rounding: DefaultRoundingMethod,
+        Residue:  residue,
+    }, nil
+}
+
+// lookupFloatRate fetches the float64 rate a CurrencyConverter supplies, with its
+// provenance when the converter implements RateInfoProvider
+func lookupFloatRate(converter CurrencyConverter, from, to string, date *time.Time) (RateInfo, float64, error) {
+    var info RateInfo
+    var rate float64
+    var err error
+    if infoProvider, ok := converter.(RateInfoProvider); ok {
+        info, err = infoProvider.GetRateInfo(from, to, date)
+        rate = info.Rate.Float64()
+    } else if rate, err = converter.GetRate(from, to, date); err == nil {
+        info = RateInfo{From: from, To: to, Provider: providerName(converter)}
+        info.Rate, err = RateFromFloat(rate)
+    }
+    if err != nil {
+        return RateInfo{}, 0, &ValidationError{
+            Field:   "exchange_rate",
+            Message: fmt.Sprintf("could not fetch rate from %s to %s: %v", from, to, err),
+        }
+    }
+    return info, rate, nil
+}
+
+// ConvertViaReferenceExactWithResult converts through a reference currency like
+// ConvertViaReferenceExact, using provider (DefaultRateProvider or DefaultConverter
+// when nil), and returns an audit record. The rates are multiplied exactly and the
+// target is rounded once, so the reference leg's Amount is informational only.
+func (m *Money) ConvertViaReferenceExactWithResult(provider RateProvider, targetCurrency, referenceCurrency string, date *time.Time) (*ConversionResult, error) {
+    if provider == nil {
+        provider = defaultRateProvider()
+    }
+    if provider == nil {
+        return nil, &ValidationError{
+            Field:   "converter",
+            Message: "no rate provider or currency converter configured",
+        }
+    }
+
+    toReference, err := lookupRateInfo(provider, m.currency.code(), referenceCurrency, date)
+    if err != nil {
+        return nil, &ValidationError{
+            Field:   "exchange_rate",
+            Message: fmt.Sprintf("could not fetch rate from %s to %s: %v", m.currency.code(), referenceCurrency, err),
+        }
+    }
+    fromReference, err := lookupRateInfo(provider, referenceCurrency, targetCurrency, date)
+    if err != nil {
+        return nil, &ValidationError{
+            Field:   "exchange_rate",
+            Message: fmt.Sprintf("could not fetch rate from %s to %s: %v", referenceCurrency, targetCurrency, err),
+        }
+    }
+    return m.convertWithLegs(targetCurrency, date, []RateInfo{toReference, fromReference})
+}
+
+// convertWithLegs applies the product of the leg rates exactly, rounding once.
+// Each intermediate leg's Amount is the source converted up to that leg, rounded
+// for reporting only; it is not used to compute the target.
+func (m *Money) convertWithLegs(targetCurrency string, date *time.Time, infos []RateInfo) (*ConversionResult, error) {
+    targetCurrencyObj, err := GetCurrency(targetCurrency)
+    if err != nil {
+        return nil, err
+    }
+
+    legs := make([]ConversionLeg, len(infos))
+    overall := Rate{r: big.NewRat(1, 1)}
+    for i, info := range infos {
+        overall = overall.Mul(info.Rate)
+        legs[i].RateInfo = info
+        if i == len(infos)-1 {
+            continue
+        }
+        legs[i].Amount, err = m.ConvertToRate(info.To, overall)
+        if err != nil {
+            return nil, err
+        }
+    }
+
+    target, residue, err := m.convertExact(targetCurrencyObj, overall)
+    if err != nil {
+        return nil, err
+    }
+    legs[len(legs)-1].Amount = target
+
+    source := *m
+    return &ConversionResult{
+        Source:   &source,
+        Target:   target,
+        Rate:     overall,
+        Legs:     legs,
+        Date:     date,
+        Rounding: DefaultRoundingMethod,
+        Residue:  residue,
+    }, nil
+}
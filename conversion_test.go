@@ -0,0 +1,174 @@
+package money
+
+import (
+    "encoding/json"
+    "errors"
+    "math"
+    "testing"
+    "time"
+)
+
+// referenceTable returns a RateTable with BRL→USD and USD→JPY rates effective on different days
+func referenceTable(t *testing.T) *RateTable {
+    t.Helper()
+    table := &RateTable{Name: "test"}
+    if err := table.SetRate("BRL", "USD", MustParseRate("0.2"), day(2024, 1, 1)); err != nil {
+        t.Fatal(err)
+    }
+    if err := table.SetRate("USD", "JPY", MustParseRate("150.5"), day(2024, 1, 2)); err != nil {
+        t.Fatal(err)
+    }
+    return table
+}
+
+func TestConvertWithResult(t *testing.T) {
+    tests := []struct {
+        name        string
+        amount      int64
+        rate        string
+        wantTarget  int64
+        wantResidue string
+    }{
+        {"exact", 10000, "1.0837", 10837, "0"},
+        {"rounded up", 10000, "1.08375", 10838, "-0.5"},
+        {"rounded down", 10000, "1.08374", 10837, "0.4"},
+        {"non-terminating", 100, "1/3", 33, "1/3"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            result, err := mustNew(t, tt.amount, "EUR").ConvertWithResult("USD", MustParseRate(tt.rate))
+            if err != nil {
+                t.Fatal(err)
+            }
+            if result.Target.Amount() != tt.wantTarget || result.Residue.Cmp(MustParseRate(tt.wantResidue)) != 0 {
+                t.Errorf("ConvertWithResult() = %d residue %v, want %d residue %s", result.Target.Amount(), result.Residue, tt.wantTarget, tt.wantResidue)
+            }
+            if len(result.Legs) != 1 || result.Legs[0].Provider != "manual" || result.Legs[0].Amount != result.Target {
+                t.Errorf("ConvertWithResult() legs = %+v", result.Legs)
+            }
+            if result.Source.Amount() != tt.amount || result.Rounding != DefaultRoundingMethod {
+                t.Errorf("ConvertWithResult() source %v rounding %v", result.Source, result.Rounding)
+            }
+        })
+    }
+}
+
+func TestConvertWithProvider(t *testing.T) {
+    table := referenceTable(t)
+    date := day(2024, 1, 5)
+    result, err := mustNew(t, 10000, "USD").ConvertWithProvider(table, "BRL", &date)
+    if err != nil {
+        t.Fatal(err)
+    }
+    leg := result.Legs[0]
+    if result.Target.Amount() != 50000 || leg.Provider != "test" || !leg.EffectiveDate.Equal(day(2024, 1, 1)) || *result.Date != date {
+        t.Errorf("ConvertWithProvider() = %d via %s effective %v", result.Target.Amount(), leg.Provider, leg.EffectiveDate)
+    }
+
+    var validation *ValidationError
+    if _, err := mustNew(t, 100, "USD").ConvertWithProvider(table, "CHF", nil); !errors.As(err, &validation) || validation.Field != "exchange_rate" {
+        t.Errorf("ConvertWithProvider() for a missing rate error = %v, want exchange_rate ValidationError", err)
+    }
+
+    savedProvider, savedConverter := DefaultRateProvider, DefaultConverter
+    defer func() { DefaultRateProvider, DefaultConverter = savedProvider, savedConverter }()
+    DefaultRateProvider, DefaultConverter = nil, nil
+    if _, err := mustNew(t, 100, "USD").ConvertWithProvider(nil, "BRL", nil); !errors.As(err, &validation) || validation.Field != "converter" {
+        t.Errorf("ConvertWithProvider() without a provider error = %v, want converter ValidationError", err)
+    }
+}
+
+func TestConvertViaReferenceWithResult(t *testing.T) {
+    tests := []struct {
+        name          string
+        converter     CurrencyConverter
+        wantProvider  string
+        wantEffective [2]time.Time
+    }{
+        {"info provider", referenceTable(t), "test", [2]time.Time{day(2024, 1, 1), day(2024, 1, 2)}},
+        {"plain converter", mapConverter{{From: "BRL", To: "USD"}: 0.2, {From: "USD", To: "JPY"}: 150.5}, "money.mapConverter", [2]time.Time{}},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            source := mustNew(t, 333, "BRL")
+            result, err := source.ConvertViaReferenceWithResult(tt.converter, "JPY", "USD", nil)
+            if err != nil {
+                t.Fatal(err)
+            }
+
+            // 3.33 BRL is 0.666 USD, rounded to 0.67, and 0.67 USD is 100.835 JPY, rounded to 101
+            if result.Target.Amount() != 101 || result.Legs[0].Amount.Amount() != 67 {
+                t.Errorf("target %d reference %d, want 101 and 67", result.Target.Amount(), result.Legs[0].Amount.Amount())
+            }
+            if residue := result.Residue.Float64(); math.Abs(residue-(100.835-101)) > 1e-9 {
+                t.Errorf("Residue = %v, want -0.165", result.Residue)
+            }
+            if result.Rate.Cmp(MustParseRate("30.1")) != 0 {
+                t.Errorf("Rate = %v, want 30.1", result.Rate)
+            }
+            for i, leg := range result.Legs {
+                if leg.Provider != tt.wantProvider || !leg.EffectiveDate.Equal(tt.wantEffective[i]) {
+                    t.Errorf("leg %d from %s effective %v, want %s effective %v", i, leg.Provider, leg.EffectiveDate, tt.wantProvider, tt.wantEffective[i])
+                }
+            }
+
+            savedConverter := DefaultConverter
+            defer func() { DefaultConverter = savedConverter }()
+            DefaultConverter = tt.converter
+            plain, err := source.ConvertViaReference("JPY", "USD", nil)
+            if err != nil {
+                t.Fatal(err)
+            }
+            if *plain != *result.Target {
+                t.Errorf("ConvertViaReference() = %v, want %v as recorded", plain, result.Target)
+            }
+        })
+    }
+}
+
+func TestConvertViaReferenceWithResultErrors(t *testing.T) {
+    converter := mapConverter{{From: "BRL", To: "USD"}: 0.2}
+    var validation *ValidationError
+    if _, err := mustNew(t, 333, "BRL").ConvertViaReferenceWithResult(converter, "JPY", "USD", nil); !errors.As(err, &validation) || validation.Field != "exchange_rate" {
+        t.Errorf("missing second leg error = %v, want exchange_rate ValidationError", err)
+    }
+
+    saved := DefaultConverter
+    defer func() { DefaultConverter = saved }()
+    DefaultConverter = nil
+    if _, err := mustNew(t, 333, "BRL").ConvertViaReferenceWithResult(nil, "JPY", "USD", nil); !errors.As(err, &validation) || validation.Field != "converter" {
+        t.Errorf("no converter error = %v, want converter ValidationError", err)
+    }
+}
+
+func TestConvertViaReferenceExactWithResult(t *testing.T) {
+    result, err := mustNew(t, 333, "BRL").ConvertViaReferenceExactWithResult(referenceTable(t), "JPY", "USD", nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    // Rounded once from 100.233 JPY; the 0.67 USD reference amount is informational
+    if result.Target.Amount() != 100 || result.Residue.Cmp(MustParseRate("0.233")) != 0 || result.Legs[0].Amount.Amount() != 67 {
+        t.Errorf("ConvertViaReferenceExactWithResult() = %d residue %v reference %d", result.Target.Amount(), result.Residue, result.Legs[0].Amount.Amount())
+    }
+}
+
+func TestConversionResultJSON(t *testing.T) {
+    date := day(2024, 1, 5)
+    result, err := mustNew(t, 333, "BRL").ConvertViaReferenceExactWithResult(referenceTable(t), "JPY", "USD", &date)
+    if err != nil {
+        t.Fatal(err)
+    }
+    data, err := json.Marshal(result)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    var decoded ConversionResult
+    if err := json.Unmarshal(data, &decoded); err != nil {
+        t.Fatal(err)
+    }
+    if *decoded.Target != *result.Target || decoded.Rate.Cmp(result.Rate) != 0 || decoded.Residue.Cmp(result.Residue) != 0 ||
+        len(decoded.Legs) != 2 || decoded.Legs[1].Provider != "test" || !decoded.Date.Equal(date) {
+        t.Errorf("JSON round trip = %s", data)
+    }
+}
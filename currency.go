@@ -3,7 +3,9 @@ package money
 import (
     "fmt"
     "math"
+    "math/big"
     "strings"
+    "sync"
     "time"
 )
 
@@ -25,6 +27,56 @@ func GetCurrency(code string) (Currency, error) {
     return currency, nil
 }
 
+// currencyHandle is an interned reference to a currency code.
+// The zero handle means no currency.
+type currencyHandle uint16
+
+var (
+    handleMu    sync.RWMutex
+    handleCodes = []string{""}
+    handleIndex = map[string]currencyHandle{}
+)
+
+// internCurrency returns the handle for a currency code, assigning one on first use
+func internCurrency(code string) currencyHandle {
+    handleMu.RLock()
+    handle, exists := handleIndex[code]
+    handleMu.RUnlock()
+    if exists {
+        return handle
+    }
+
+    handleMu.Lock()
+    defer handleMu.Unlock()
+    if handle, exists := handleIndex[code]; exists {
+        return handle
+    }
+    if len(handleCodes) > math.MaxUint16 {
+        panic("money: too many distinct currencies interned")
+    }
+    handle = currencyHandle(len(handleCodes))
+    handleCodes = append(handleCodes, code)
+    handleIndex[code] = handle
+    return handle
+}
+
+// code returns the currency code the handle refers to
+func (h currencyHandle) code() string {
+    handleMu.RLock()
+    defer handleMu.RUnlock()
+    return handleCodes[h]
+}
+
+// details returns the current CurrencyMap entry for the handle, so edits to
+// CurrencyMap are visible to existing Money values
+func (h currencyHandle) details() Currency {
+    code := h.code()
+    if currency, exists := CurrencyMap[code]; exists {
+        return currency
+    }
+    return Currency{Code: code}
+}
+
 // ConvertTo converts Money to another currency given an exchange rate
 func (m *Money) ConvertTo(targetCurrency string, rate float64) (*Money, error) {
     targetCurrencyObj, err := GetCurrency(targetCurrency)
@@ -34,19 +86,30 @@ func (m *Money) ConvertTo(targetCurrency string, rate float64) (*Money, error) {
 
     // Special handling for Brazilian Real conversions
     if targetCurrencyObj.Code == "BRL" {
-        targetAmount := round(int64(float64(m.amount)*rate*10), DefaultRoundingMethod) / 10
+        targetAmount := convertAmount(m.amount, rate, m.currency.details(), targetCurrencyObj)
         // Apply Brazilian rounding to the final amount
         targetAmount = formatBrazilianAmount(targetAmount)
-        return &Money{amount: targetAmount, currency: targetCurrencyObj}, nil
+        return &Money{amount: targetAmount, currency: internCurrency(targetCurrencyObj.Code)}, nil
     }
 
-    targetAmount := round(int64(float64(m.amount)*rate*10), DefaultRoundingMethod) / 10
-    return &Money{amount: targetAmount, currency: targetCurrencyObj}, nil
+    targetAmount := convertAmount(m.amount, rate, m.currency.details(), targetCurrencyObj)
+    return &Money{amount: targetAmount, currency: internCurrency(targetCurrencyObj.Code)}, nil
+}
+
+// convertAmount applies a rate to an amount in minor units, rescaling between the
+// precisions of the two currencies and rounding with DefaultRoundingMethod
+func convertAmount(amount int64, rate float64, from, to Currency) int64 {
+    return round(int64(scaledAmount(amount, rate, from, to)*10), DefaultRoundingMethod)
+}
+
+// scaledAmount is the unrounded float64 result of convertAmount, in target minor units
+func scaledAmount(amount int64, rate float64, from, to Currency) float64 {
+    return float64(amount) * rate * math.Pow10(to.Precision-from.Precision)
 }
 
 // ConvertViaReference converts to another currency using a reference currency and optional date.
 // It uses the DefaultConverter to get exchange rates. Returns an error if no converter is configured
-// or if any conversion fails.
+// or if any conversion fails. Use ConvertViaReferenceWithResult for an audit record of the conversion.
 func (m *Money) ConvertViaReference(targetCurrency, referenceCurrency string, date *time.Time) (*Money, error) {
     if DefaultConverter == nil {
         return nil, &ValidationError{
@@ -55,49 +118,78 @@ func (m *Money) ConvertViaReference(targetCurrency, referenceCurrency string, da
         }
     }
 
-    toReferenceRate, err := DefaultConverter.GetRate(m.currency.Code, referenceCurrency, date)
+    result, err := m.ConvertViaReferenceWithResult(DefaultConverter, targetCurrency, referenceCurrency, date)
     if err != nil {
-        return nil, &ValidationError{
-            Field:   "exchange_rate",
-            Message: fmt.Sprintf("could not fetch rate from %s to %s: %v", m.currency.Code, referenceCurrency, err),
-        }
+        return nil, err
     }
+    return result.Target, nil
+}
 
-    referenceAmount := round(int64(float64(m.amount)*toReferenceRate*10), DefaultRoundingMethod) / 10
-    fromReferenceRate, err := DefaultConverter.GetRate(referenceCurrency, targetCurrency, date)
+// ConvertToRate converts Money to another currency with an exact rate.
+// The result is computed exactly and rounded once with DefaultRoundingMethod.
+func (m *Money) ConvertToRate(targetCurrency string, rate Rate) (*Money, error) {
+    targetCurrencyObj, err := GetCurrency(targetCurrency)
     if err != nil {
-        return nil, &ValidationError{
-            Field:   "exchange_rate",
-            Message: fmt.Sprintf("could not fetch rate from %s to %s: %v", referenceCurrency, targetCurrency, err),
-        }
+        return nil, err
     }
+    target, _, err := m.convertExact(targetCurrencyObj, rate)
+    return target, err
+}
 
-    targetCurrencyObj, err := GetCurrency(targetCurrency)
+// convertExact applies an exact rate and rounds once, returning the target amount
+// and the residue left by rounding (exact minus rounded, in target minor units)
+func (m *Money) convertExact(targetCurrencyObj Currency, rate Rate) (*Money, Rate, error) {
+    exact := exactAmount(m.amount, rate, m.currency.details(), targetCurrencyObj)
+    targetAmount, err := roundRat(exact, DefaultRoundingMethod)
     if err != nil {
-        return nil, err
+        return nil, Rate{}, err
     }
 
-    targetAmount := round(int64(float64(referenceAmount)*fromReferenceRate*10), DefaultRoundingMethod) / 10
-    
     // Apply Brazilian rounding if converting to BRL
     if targetCurrencyObj.Code == "BRL" {
         targetAmount = formatBrazilianAmount(targetAmount)
     }
 
-    return &Money{amount: targetAmount, currency: targetCurrencyObj}, nil
+    residue := new(big.Rat).Sub(exact, new(big.Rat).SetInt64(targetAmount))
+    return &Money{amount: targetAmount, currency: internCurrency(targetCurrencyObj.Code)}, Rate{r: residue}, nil
+}
+
+// exactAmount applies a rate to an amount in minor units without rounding,
+// rescaling between the precisions of the two currencies
+func exactAmount(amount int64, rate Rate, from, to Currency) *big.Rat {
+    result := new(big.Rat).SetInt64(amount)
+    result.Mul(result, rate.rat())
+    scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(to.Precision-from.Precision))), nil))
+    if to.Precision >= from.Precision {
+        return result.Mul(result, scale)
+    }
+    return result.Quo(result, scale)
+}
+
+// ConvertViaReferenceExact converts to another currency through a reference currency
+// using exact rates from DefaultRateProvider (or DefaultConverter when no provider is set).
+// Unlike ConvertViaReference, the two rates are multiplied exactly and the result is
+// rounded only once.
+func (m *Money) ConvertViaReferenceExact(targetCurrency, referenceCurrency string, date *time.Time) (*Money, error) {
+    result, err := m.ConvertViaReferenceExactWithResult(nil, targetCurrency, referenceCurrency, date)
+    if err != nil {
+        return nil, err
+    }
+    return result.Target, nil
 }
 
 // FormatWithOptions formats Money with custom options
 func (m *Money) FormatWithOptions(opts MoneyFormatOptions) string {
     amount := m.amount
+    currency := m.currency.details()
 
     // Apply Brazilian rounding for BRL currency on final display
-    if m.currency.Code == "BRL" {
+    if currency.Code == "BRL" {
         amount = formatBrazilianAmount(amount)
     }
 
-    units := amount / int64(math.Pow10(m.currency.Precision))
-    decimals := amount % int64(math.Pow10(m.currency.Precision))
+    units := amount / int64(math.Pow10(currency.Precision))
+    decimals := amount % int64(math.Pow10(currency.Precision))
     if decimals < 0 {
         decimals = -decimals
     }
@@ -116,9 +208,10 @@ func (m *Money) FormatWithOptions(opts MoneyFormatOptions) string {
     }
 
     // Add currency symbol if requested
+    symbol := currency.DisplaySymbol(opts.SymbolStyle, opts.HomeCurrency)
     if opts.UseSymbol {
         if opts.SymbolPosition == "before" {
-            result += m.currency.Symbol + " "
+            result += symbol + " "
         }
     }
 
@@ -130,14 +223,14 @@ func (m *Money) FormatWithOptions(opts MoneyFormatOptions) string {
     result += amountStr
 
     // Add decimal places if needed
-    if opts.ShowCents && m.currency.Precision > 0 {
-        decimalPart := fmt.Sprintf("%0*d", m.currency.Precision, decimals)
+    if opts.ShowCents && currency.Precision > 0 {
+        decimalPart := fmt.Sprintf("%0*d", currency.Precision, decimals)
         result += opts.DecimalSeparator + decimalPart
     }
 
     // Add currency symbol after if specified
     if opts.UseSymbol && opts.SymbolPosition == "after" {
-        result += " " + m.currency.Symbol
+        result += " " + symbol
     }
 
     return result
@@ -145,13 +238,99 @@ func (m *Money) FormatWithOptions(opts MoneyFormatOptions) string {
 
 // Format returns a string representation using default formatting options for the currency
 func (m *Money) Format() string {
-    return m.FormatWithOptions(MoneyFormatOptions{
+    return m.FormatWithOptions(m.defaultFormatOptions())
+}
+
+// defaultFormatOptions returns the formatting options native to the currency
+func (m *Money) defaultFormatOptions() MoneyFormatOptions {
+    currency := m.currency.details()
+    return MoneyFormatOptions{
         UseSymbol:        true,
         ShowCents:        true,
-        SymbolPosition:   m.currency.SymbolPosition,
-        GroupSeparator:   m.currency.GroupSeparator,
-        DecimalSeparator: m.currency.DecimalSeparator,
-    })
+        SymbolPosition:   currency.SymbolPosition,
+        GroupSeparator:   currency.GroupSeparator,
+        DecimalSeparator: currency.DecimalSeparator,
+    }
+}
+
+// FormatDual formats Money followed by its approximate value in a secondary currency,
+// e.g. "€ 45,00 (≈ US$ 48.60)". The primary amount uses opts, while the secondary
+// amount uses its own currency's separators and symbol position. The rate is taken
+// from converter, or DefaultConverter when converter is nil. If no rate can be
+// obtained, only the primary amount is returned.
+func (m *Money) FormatDual(secondaryCode string, converter CurrencyConverter, date *time.Time, opts MoneyFormatOptions) string {
+    primaryOpts := opts
+    primary := m.FormatWithOptions(primaryOpts)
+    if secondaryCode == m.currency.code() {
+        return primary
+    }
+
+    if converter == nil {
+        converter = DefaultConverter
+    }
+    if converter == nil {
+        return primary
+    }
+    rate, err := converter.GetRate(m.currency.code(), secondaryCode, date)
+    if err != nil {
+        return primary
+    }
+    secondary, err := m.ConvertTo(secondaryCode, rate)
+    if err != nil {
+        return primary
+    }
+
+    secondaryOpts := secondary.defaultFormatOptions()
+    secondaryOpts.UseSymbol = opts.UseSymbol
+    secondaryOpts.ShowCents = opts.ShowCents
+    secondaryOpts.SymbolStyle = opts.SymbolStyle
+    secondaryOpts.HomeCurrency = opts.HomeCurrency
+
+    // Both symbols appear side by side, so a shared symbol must be disambiguated
+    if opts.SymbolStyle == SymbolAuto && m.currency.details().Symbol == secondary.currency.details().Symbol {
+        primaryOpts.SymbolStyle = SymbolInternational
+        secondaryOpts.SymbolStyle = SymbolInternational
+        primary = m.FormatWithOptions(primaryOpts)
+    }
+
+    return primary + " (≈ " + secondary.FormatWithOptions(secondaryOpts) + ")"
+}
+
+// DisplaySymbol returns the symbol to show for the given style.
+// For SymbolAuto the international form is used whenever the standard symbol
+// is shared with another currency, unless this currency is the reader's home currency.
+// Missing narrow or international symbols fall back to the standard symbol.
+func (c Currency) DisplaySymbol(style SymbolStyle, homeCurrency string) string {
+    switch style {
+    case SymbolNarrow:
+        if c.NarrowSymbol != "" {
+            return c.NarrowSymbol
+        }
+    case SymbolInternational:
+        if c.InternationalSymbol != "" {
+            return c.InternationalSymbol
+        }
+    case SymbolAuto:
+        if c.Code != homeCurrency && IsSymbolAmbiguous(c.Code) && c.InternationalSymbol != "" {
+            return c.InternationalSymbol
+        }
+    }
+    return c.Symbol
+}
+
+// IsSymbolAmbiguous reports whether the standard symbol of a currency is shared
+// with any other currency in CurrencyMap
+func IsSymbolAmbiguous(code string) bool {
+    currency, exists := CurrencyMap[code]
+    if !exists {
+        return false
+    }
+    for otherCode, other := range CurrencyMap {
+        if otherCode != code && other.Symbol == currency.Symbol {
+            return true
+        }
+    }
+    return false
 }
 
 // Helper for thousands separator
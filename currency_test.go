@@ -0,0 +1,226 @@
+package money
+
+import (
+    "testing"
+    "time"
+)
+
+func TestDisplaySymbol(t *testing.T) {
+    tests := []struct {
+        name  string
+        code  string
+        style SymbolStyle
+        home  string
+        want  string
+    }{
+        {"standard dollar", "USD", SymbolStandard, "", "$"},
+        {"narrow dollar", "CAD", SymbolNarrow, "", "$"},
+        {"international dollar", "CAD", SymbolInternational, "", "CA$"},
+        {"international peso", "ARS", SymbolInternational, "", "AR$"},
+        {"auto ambiguous dollar", "USD", SymbolAuto, "", "US$"},
+        {"auto home currency keeps standard", "USD", SymbolAuto, "USD", "$"},
+        {"auto foreign while home is another dollar", "USD", SymbolAuto, "CAD", "US$"},
+        {"auto unambiguous symbol", "EUR", SymbolAuto, "", "€"},
+        {"auto unambiguous real", "BRL", SymbolAuto, "USD", "R$"},
+        {"narrow Hong Kong dollar", "HKD", SymbolNarrow, "", "$"},
+        {"standard Hong Kong dollar", "HKD", SymbolStandard, "", "HK$"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got := CurrencyMap[tt.code].DisplaySymbol(tt.style, tt.home)
+            if got != tt.want {
+                t.Errorf("DisplaySymbol(%v, %q) for %s = %q, want %q", tt.style, tt.home, tt.code, got, tt.want)
+            }
+        })
+    }
+}
+
+func TestDisplaySymbolFallsBackToStandard(t *testing.T) {
+    currency := Currency{Code: "XTS", Symbol: "¤"}
+    for _, style := range []SymbolStyle{SymbolStandard, SymbolNarrow, SymbolInternational, SymbolAuto} {
+        if got := currency.DisplaySymbol(style, ""); got != "¤" {
+            t.Errorf("DisplaySymbol(%v) = %q, want %q", style, got, "¤")
+        }
+    }
+}
+
+func TestIsSymbolAmbiguous(t *testing.T) {
+    tests := []struct {
+        code string
+        want bool
+    }{
+        {"USD", true},
+        {"ARS", true},
+        {"SEK", true},
+        {"JPY", true},
+        {"EUR", false},
+        {"GBP", false},
+        {"BRL", false},
+        {"HKD", false},
+        {"XXX", false},
+    }
+    for _, tt := range tests {
+        t.Run(tt.code, func(t *testing.T) {
+            if got := IsSymbolAmbiguous(tt.code); got != tt.want {
+                t.Errorf("IsSymbolAmbiguous(%q) = %v, want %v", tt.code, got, tt.want)
+            }
+        })
+    }
+}
+
+func TestFormatDual(t *testing.T) {
+    date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+    rates := NewRateTable()
+    for _, r := range []struct {
+        from, to string
+        rate     float64
+    }{
+        {"EUR", "USD", 1.08},
+        {"USD", "CAD", 1.35},
+        {"USD", "JPY", 150},
+    } {
+        if err := rates.Set(r.from, r.to, r.rate, date); err != nil {
+            t.Fatal(err)
+        }
+    }
+
+    tests := []struct {
+        name      string
+        amount    int64
+        code      string
+        secondary string
+        converter CurrencyConverter
+        opts      MoneyFormatOptions
+        want      string
+    }{
+        {
+            name: "secondary uses its own separators", amount: 4500, code: "EUR", secondary: "USD", converter: rates,
+            opts: MoneyFormatOptions{UseSymbol: true, ShowCents: true, SymbolPosition: "before", GroupSeparator: ".", DecimalSeparator: ","},
+            want: "€ 45,00 (≈ $ 48.60)",
+        },
+        {
+            name: "auto style disambiguates shared symbols", amount: 10000, code: "USD", secondary: "CAD", converter: rates,
+            opts: MoneyFormatOptions{UseSymbol: true, ShowCents: true, SymbolPosition: "before", GroupSeparator: ",", DecimalSeparator: ".", SymbolStyle: SymbolAuto},
+            want: "US$ 100.00 (≈ CA$ 135.00)",
+        },
+        {
+            name: "inverse rate and zero-decimal secondary", amount: 15000, code: "JPY", secondary: "USD", converter: rates,
+            opts: MoneyFormatOptions{UseSymbol: true, ShowCents: true, SymbolPosition: "before", GroupSeparator: ","},
+            want: "¥ 15,000 (≈ $ 100.00)",
+        },
+        {
+            name: "missing rate shows primary only", amount: 4500, code: "EUR", secondary: "GBP", converter: rates,
+            opts: MoneyFormatOptions{UseSymbol: true, ShowCents: true, SymbolPosition: "before", GroupSeparator: ".", DecimalSeparator: ","},
+            want: "€ 45,00",
+        },
+        {
+            name: "same currency shows primary only", amount: 4500, code: "EUR", secondary: "EUR", converter: rates,
+            opts: MoneyFormatOptions{UseSymbol: true, ShowCents: true, SymbolPosition: "before", GroupSeparator: ".", DecimalSeparator: ","},
+            want: "€ 45,00",
+        },
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            m, err := New(tt.amount, tt.code)
+            if err != nil {
+                t.Fatal(err)
+            }
+            if got := m.FormatDual(tt.secondary, tt.converter, &date, tt.opts); got != tt.want {
+                t.Errorf("FormatDual() = %q, want %q", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestFormatDualWithoutConverter(t *testing.T) {
+    saved := DefaultConverter
+    DefaultConverter = nil
+    defer func() { DefaultConverter = saved }()
+
+    m, err := New(4500, "EUR")
+    if err != nil {
+        t.Fatal(err)
+    }
+    want := m.Format()
+    if got := m.FormatDual("USD", nil, nil, m.defaultFormatOptions()); got != want {
+        t.Errorf("FormatDual() without a converter = %q, want %q", got, want)
+    }
+}
+
+func TestConvertToRescalesPrecision(t *testing.T) {
+    tests := []struct {
+        name       string
+        amount     int64
+        from, to   string
+        rate       float64
+        wantAmount int64
+    }{
+        {"same precision", 4500, "EUR", "USD", 1.08, 4860},
+        {"to zero decimals", 10000, "USD", "JPY", 150, 15000},
+        {"from zero decimals", 15000, "JPY", "USD", 1.0 / 150, 10000},
+        {"to three decimals", 10000, "USD", "KWD", 0.3075, 30750},
+        {"rounds half up", 1, "USD", "EUR", 0.5, 1},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            m, err := New(tt.amount, tt.from)
+            if err != nil {
+                t.Fatal(err)
+            }
+            got, err := m.ConvertTo(tt.to, tt.rate)
+            if err != nil {
+                t.Fatal(err)
+            }
+            if got.Amount() != tt.wantAmount || got.Currency().Code != tt.to {
+                t.Errorf("ConvertTo(%s, %v) = %d %s, want %d %s", tt.to, tt.rate, got.Amount(), got.Currency().Code, tt.wantAmount, tt.to)
+            }
+        })
+    }
+}
+
+func TestInternCurrency(t *testing.T) {
+    usd, eur := internCurrency("USD"), internCurrency("EUR")
+    if usd == 0 || eur == 0 || usd == eur {
+        t.Fatalf("internCurrency() handles USD=%d EUR=%d, want distinct non-zero handles", usd, eur)
+    }
+    if again := internCurrency("USD"); again != usd {
+        t.Errorf("internCurrency(USD) = %d on second use, want %d", again, usd)
+    }
+
+    tests := []struct {
+        handle currencyHandle
+        want   string
+    }{
+        {0, ""},
+        {usd, "USD"},
+        {eur, "EUR"},
+    }
+    for _, tt := range tests {
+        if got := tt.handle.code(); got != tt.want {
+            t.Errorf("handle %d code() = %q, want %q", tt.handle, got, tt.want)
+        }
+    }
+
+    if a, b := *mustNew(t, 100, "USD"), *mustNew(t, 100, "USD"); a != b {
+        t.Error("equal Money values compare unequal with ==")
+    }
+    if a, b := *mustNew(t, 100, "USD"), *mustNew(t, 100, "CAD"); a == b {
+        t.Error("Money values in different currencies compare equal with ==")
+    }
+}
+
+func TestCurrencyHandleSeesCurrencyMapEdits(t *testing.T) {
+    CurrencyMap["XTS"] = Currency{Code: "XTS", Symbol: "¤", Precision: 2}
+    defer delete(CurrencyMap, "XTS")
+
+    m := mustNew(t, 150, "XTS")
+    CurrencyMap["XTS"] = Currency{Code: "XTS", Symbol: "¤", Precision: 3}
+    if got := m.Currency().Precision; got != 3 {
+        t.Errorf("Currency().Precision after editing CurrencyMap = %d, want 3", got)
+    }
+
+    delete(CurrencyMap, "XTS")
+    if got := m.Currency(); got.Code != "XTS" {
+        t.Errorf("Currency() after removing the CurrencyMap entry = %+v, want code XTS", got)
+    }
+}
@@ -0,0 +1,101 @@
+package money
+
+import (
+    "encoding/xml"
+    "fmt"
+    "io"
+    "time"
+)
+
+// ECBProviderName is reported as the provider of rates read from ECB files
+const ECBProviderName = "ECB"
+
+// ecbEnvelope mirrors the structure of the ECB eurofxref files:
+// Envelope > Cube > Cube time="..." > Cube currency="..." rate="..."
+type ecbEnvelope struct {
+    Days []struct {
+        Time  string `xml:"time,attr"`
+        Rates []struct {
+            Currency string `xml:"currency,attr"`
+            Rate     string `xml:"rate,attr"`
+        } `xml:"Cube"`
+    } `xml:"Cube>Cube"`
+}
+
+// ReadECBRates parses an ECB euro foreign exchange reference rate file, such as
+// eurofxref-daily.xml, eurofxref-hist-90d.xml or eurofxref-hist.xml. Each rate is
+// returned as EUR→currency, read exactly from its decimal text and effective on
+// the day it was published. Currencies not present in CurrencyMap are skipped.
+func ReadECBRates(r io.Reader) ([]RateInfo, error) {
+    var envelope ecbEnvelope
+    if err := xml.NewDecoder(r).Decode(&envelope); err != nil {
+        return nil, &ValidationError{
+            Field:   "ecb",
+            Message: fmt.Sprintf("invalid ECB rate file: %v", err),
+        }
+    }
+
+    var result []RateInfo
+    for _, day := range envelope.Days {
+        date, err := time.Parse("2006-01-02", day.Time)
+        if err != nil {
+            return nil, &ValidationError{
+                Field:   "time",
+                Message: fmt.Sprintf("invalid ECB rate date %q", day.Time),
+            }
+        }
+        for _, entry := range day.Rates {
+            if _, err := GetCurrency(entry.Currency); err != nil {
+                continue
+            }
+            rate, err := ParseRate(entry.Rate)
+            if err != nil || !rate.IsPositive() {
+                return nil, &ValidationError{
+                    Field:   "rate",
+                    Message: fmt.Sprintf("invalid ECB rate %q for %s on %s", entry.Rate, entry.Currency, day.Time),
+                }
+            }
+            result = append(result, RateInfo{
+                From:          "EUR",
+                To:            entry.Currency,
+                Rate:          rate,
+                Provider:      ECBProviderName,
+                EffectiveDate: date,
+            })
+        }
+    }
+
+    if len(result) == 0 {
+        return nil, &ValidationError{
+            Field:   "ecb",
+            Message: "ECB rate file contains no known currencies",
+        }
+    }
+    return result, nil
+}
+
+// LoadECB reads an ECB reference rate file into the table. Nothing is loaded if the
+// file is invalid. Base defaults to EUR so that other pairs are crossed through the euro.
+func (t *RateTable) LoadECB(r io.Reader) error {
+    rates, err := ReadECBRates(r)
+    if err != nil {
+        return err
+    }
+    return t.loadRates(rates, "EUR")
+}
+
+// loadRates stores a batch of rates read by one of the file loaders, defaulting
+// Base to base when it is unset
+func (t *RateTable) loadRates(rates []RateInfo, base string) error {
+    for _, info := range rates {
+        if err := t.SetRate(info.From, info.To, info.Rate, info.EffectiveDate); err != nil {
+            return err
+        }
+    }
+    t.mu.Lock()
+    defer t.mu.Unlock()
+    if t.Base == "" {
+        t.Base = base
+    }
+    return nil
+}
@@ -0,0 +1,144 @@
+package money
+
+import (
+    "errors"
+    "strings"
+    "sync"
+    "testing"
+    "time"
+)
+
+const ecbSample = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+    <gesmes:subject>Reference rates</gesmes:subject>
+    <Cube>
+        <Cube time="2024-01-03">
+            <Cube currency="USD" rate="1.0919"/>
+            <Cube currency="JPY" rate="155.48"/>
+            <Cube currency="GBP" rate="0.86408"/>
+            <Cube currency="XYZ" rate="2.5"/>
+        </Cube>
+        <Cube time="2024-01-02">
+            <Cube currency="USD" rate="1.0956"/>
+        </Cube>
+    </Cube>
+</gesmes:Envelope>`
+
+func TestReadECBRates(t *testing.T) {
+    tests := []struct {
+        name      string
+        input     string
+        wantRates int
+        wantField string // ValidationError field when the file is rejected
+    }{
+        {"sample", ecbSample, 4, ""},
+        {"not XML", "rates", 0, "ecb"},
+        {"invalid date", `<Envelope><Cube><Cube time="3 Jan"><Cube currency="USD" rate="1.09"/></Cube></Cube></Envelope>`, 0, "time"},
+        {"invalid rate", `<Envelope><Cube><Cube time="2024-01-03"><Cube currency="USD" rate="1,09"/></Cube></Cube></Envelope>`, 0, "rate"},
+        {"zero rate", `<Envelope><Cube><Cube time="2024-01-03"><Cube currency="USD" rate="0"/></Cube></Cube></Envelope>`, 0, "rate"},
+        {"only unknown currencies", `<Envelope><Cube><Cube time="2024-01-03"><Cube currency="XYZ" rate="2.5"/></Cube></Cube></Envelope>`, 0, "ecb"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            rates, err := ReadECBRates(strings.NewReader(tt.input))
+            if tt.wantField != "" {
+                var validation *ValidationError
+                if !errors.As(err, &validation) || validation.Field != tt.wantField {
+                    t.Fatalf("ReadECBRates() error = %v, want %s ValidationError", err, tt.wantField)
+                }
+                return
+            }
+            if err != nil {
+                t.Fatal(err)
+            }
+            if len(rates) != tt.wantRates {
+                t.Fatalf("ReadECBRates() returned %d rates, want %d", len(rates), tt.wantRates)
+            }
+            first := rates[0]
+            if first.From != "EUR" || first.To != "USD" || first.Rate.String() != "1.0919" ||
+                first.Provider != ECBProviderName || !first.EffectiveDate.Equal(day(2024, 1, 3)) {
+                t.Errorf("first rate = %+v", first)
+            }
+        })
+    }
+}
+
+func TestRateTableLoadECB(t *testing.T) {
+    table := NewRateTable()
+    if err := table.LoadECB(strings.NewReader(ecbSample)); err != nil {
+        t.Fatal(err)
+    }
+    if table.Base != "EUR" {
+        t.Errorf("Base = %q, want EUR", table.Base)
+    }
+
+    earlier := day(2024, 1, 2)
+    tests := []struct {
+        name     string
+        from, to string
+        date     *time.Time
+        want     Rate
+    }{
+        {"stored", "EUR", "USD", nil, MustParseRate("1.0919")},
+        {"inverse", "USD", "EUR", nil, MustParseRate("1.0919").Inverse()},
+        {"earlier day", "EUR", "USD", &earlier, MustParseRate("1.0956")},
+        {"cross through the base", "USD", "JPY", nil, MustParseRate("1.0919").Inverse().Mul(MustParseRate("155.48"))},
+        {"cross between non-base currencies", "GBP", "JPY", nil, MustParseRate("0.86408").Inverse().Mul(MustParseRate("155.48"))},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            info, err := table.GetRateInfo(tt.from, tt.to, tt.date)
+            if err != nil {
+                t.Fatal(err)
+            }
+            if info.Rate.Cmp(tt.want) != 0 {
+                t.Errorf("GetRateInfo() = %v, want %v", info.Rate, tt.want)
+            }
+        })
+    }
+
+    // A cross rate needs both legs on the requested day
+    if _, err := table.GetRate("USD", "JPY", &earlier); err == nil {
+        t.Error("GetRate() crossed a rate missing on the requested day")
+    }
+}
+
+func TestRateTableLoadECBKeepsBase(t *testing.T) {
+    table := &RateTable{Base: "USD"}
+    if err := table.LoadECB(strings.NewReader(ecbSample)); err != nil {
+        t.Fatal(err)
+    }
+    if table.Base != "USD" {
+        t.Errorf("Base = %q, want the existing base kept", table.Base)
+    }
+
+    invalid := NewRateTable()
+    if err := invalid.LoadECB(strings.NewReader("rates")); err == nil {
+        t.Fatal("LoadECB() accepted an invalid file")
+    }
+    if rates := invalid.ListRates(nil); len(rates) != 0 || invalid.Base != "" {
+        t.Errorf("invalid file loaded %d rates with Base %q", len(rates), invalid.Base)
+    }
+}
+
+func TestRateTableLoadECBConcurrentLookups(t *testing.T) {
+    table := NewRateTable()
+    var wg sync.WaitGroup
+    for i := 0; i < 4; i++ {
+        wg.Add(2)
+        go func() {
+            defer wg.Done()
+            if err := table.LoadECB(strings.NewReader(ecbSample)); err != nil {
+                t.Error(err)
+            }
+        }()
+        go func() {
+            defer wg.Done()
+            table.GetRate("USD", "JPY", nil)
+        }()
+    }
+    wg.Wait()
+    if _, err := table.GetRate("USD", "JPY", nil); err != nil {
+        t.Errorf("GetRate() after loading = %v", err)
+    }
+}
@@ -0,0 +1,301 @@
+package money
+
+import (
+    "encoding/binary"
+    "encoding/xml"
+    "fmt"
+    "math"
+    "strings"
+)
+
+// MarshalText implements encoding.TextMarshaler using the canonical form "EUR 12.34"
+func (m Money) MarshalText() ([]byte, error) {
+    currency := m.currency.details()
+    if currency.Code == "" {
+        return nil, &ValidationError{
+            Field:   "currency",
+            Message: "cannot marshal Money without a currency",
+        }
+    }
+    return []byte(currency.Code + " " + formatDecimal(m.amount, currency.Precision)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for the canonical form "EUR 12.34"
+func (m *Money) UnmarshalText(text []byte) error {
+    fields := strings.Fields(string(text))
+    if len(fields) != 2 {
+        return &ValidationError{
+            Field:   "money",
+            Message: fmt.Sprintf("expected \"CODE AMOUNT\", got %q", string(text)),
+        }
+    }
+
+    currency, err := GetCurrency(fields[0])
+    if err != nil {
+        return err
+    }
+    amount, err := parseDecimal(fields[1], currency.Precision)
+    if err != nil {
+        return err
+    }
+
+    m.amount = amount
+    m.currency = internCurrency(currency.Code)
+    return nil
+}
+
+// MarshalXML implements xml.Marshaler, writing the amount as character data and
+// the currency code in the XMLCurrencyAttr attribute
+func (m Money) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+    currency := m.currency.details()
+    if currency.Code == "" {
+        return &ValidationError{
+            Field:   "currency",
+            Message: "cannot marshal Money without a currency",
+        }
+    }
+    start.Attr = append(start.Attr, xml.Attr{
+        Name:  xml.Name{Local: XMLCurrencyAttr},
+        Value: currency.Code,
+    })
+    return e.EncodeElement(formatDecimal(m.amount, currency.Precision), start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler for elements such as <Amt Ccy="EUR">12.34</Amt>,
+// reading the currency code from the XMLCurrencyAttr attribute
+func (m *Money) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+    code := ""
+    for _, attr := range start.Attr {
+        if attr.Name.Local == XMLCurrencyAttr {
+            code = attr.Value
+            break
+        }
+    }
+    if code == "" {
+        return &ValidationError{
+            Field:   XMLCurrencyAttr,
+            Message: fmt.Sprintf("missing currency attribute on <%s>", start.Name.Local),
+        }
+    }
+
+    var text string
+    if err := d.DecodeElement(&text, &start); err != nil {
+        return err
+    }
+
+    currency, err := GetCurrency(code)
+    if err != nil {
+        return err
+    }
+    amount, err := parseDecimal(strings.TrimSpace(text), currency.Precision)
+    if err != nil {
+        return err
+    }
+
+    m.amount = amount
+    m.currency = internCurrency(currency.Code)
+    return nil
+}
+
+// binaryFormatVersion is the first byte of the MarshalBinary encoding.
+// Version 1 is: version byte, uvarint code length, code bytes, varint amount.
+const binaryFormatVersion = 1
+
+// maxBinaryCodeLength bounds the currency code read from untrusted input
+const maxBinaryCodeLength = 16
+
+// MarshalBinary implements encoding.BinaryMarshaler with a compact, versioned format.
+// It is also used by encoding/gob.
+func (m Money) MarshalBinary() ([]byte, error) {
+    code := m.currency.code()
+    if code == "" {
+        return nil, &ValidationError{
+            Field:   "currency",
+            Message: "cannot marshal Money without a currency",
+        }
+    }
+
+    buf := make([]byte, 0, 1+1+len(code)+binary.MaxVarintLen64)
+    buf = append(buf, binaryFormatVersion)
+    buf = binary.AppendUvarint(buf, uint64(len(code)))
+    buf = append(buf, code...)
+    buf = binary.AppendVarint(buf, m.amount)
+    return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. Malformed input and
+// unknown currencies are reported as ValidationError.
+func (m *Money) UnmarshalBinary(data []byte) error {
+    invalid := func(message string) error {
+        return &ValidationError{
+            Field:   "binary money",
+            Message: message,
+        }
+    }
+
+    if len(data) == 0 {
+        return invalid("empty input")
+    }
+    if data[0] != binaryFormatVersion {
+        return invalid(fmt.Sprintf("unsupported format version %d", data[0]))
+    }
+    data = data[1:]
+
+    codeLength, n := binary.Uvarint(data)
+    if n <= 0 || codeLength > maxBinaryCodeLength || uint64(len(data)-n) < codeLength {
+        return invalid("malformed currency code")
+    }
+    code := string(data[n : n+int(codeLength)])
+    data = data[n+int(codeLength):]
+
+    amount, n := binary.Varint(data)
+    if n <= 0 {
+        return invalid("malformed amount")
+    }
+    if n != len(data) {
+        return invalid("unexpected trailing data")
+    }
+
+    if _, exists := CurrencyMap[code]; !exists {
+        return invalid(fmt.Sprintf("unknown currency %q", code))
+    }
+
+    m.amount = amount
+    m.currency = internCurrency(code)
+    return nil
+}
+
+// formatDecimal renders an amount in minor units as a plain decimal string
+// with exactly precision fractional digits, e.g. 1234 with precision 2 is "12.34"
+func formatDecimal(amount int64, precision int) string {
+    sign := ""
+    magnitude := uint64(amount)
+    if amount < 0 {
+        sign = "-"
+        magnitude = uint64(-(amount + 1)) + 1
+    }
+
+    digits := fmt.Sprintf("%0*d", precision+1, magnitude)
+    if precision == 0 {
+        return sign + digits
+    }
+    split := len(digits) - precision
+    return sign + digits[:split] + "." + digits[split:]
+}
+
+// parseDecimal parses a plain decimal string into minor units for the given precision.
+// Fractional digits beyond the precision are only accepted when they are zeros,
+// so parsing never rounds.
+func parseDecimal(s string, precision int) (int64, error) {
+    invalid := func(message string) error {
+        return &ValidationError{
+            Field:   "amount",
+            Message: fmt.Sprintf("%q: %s", s, message),
+        }
+    }
+
+    text := s
+    negative := false
+    if strings.HasPrefix(text, "-") || strings.HasPrefix(text, "+") {
+        negative = text[0] == '-'
+        text = text[1:]
+    }
+
+    integer, fraction, _ := strings.Cut(text, ".")
+    if integer == "" && fraction == "" {
+        return 0, invalid("no digits")
+    }
+    if len(fraction) > precision {
+        if strings.Trim(fraction[precision:], "0") != "" {
+            return 0, invalid(fmt.Sprintf("more than %d decimal places", precision))
+        }
+        fraction = fraction[:precision]
+    }
+    fraction += strings.Repeat("0", precision-len(fraction))
+
+    var magnitude uint64
+    for _, r := range integer + fraction {
+        if r < '0' || r > '9' {
+            return 0, invalid("not a decimal number")
+        }
+        digit := uint64(r - '0')
+        if magnitude > (math.MaxUint64-digit)/10 {
+            return 0, invalid("amount out of range")
+        }
+        magnitude = magnitude*10 + digit
+    }
+
+    if negative {
+        if magnitude > uint64(math.MaxInt64)+1 {
+            return 0, invalid("amount out of range")
+        }
+        return -int64(magnitude - 1) - 1, nil
+    }
+    if magnitude > math.MaxInt64 {
+        return 0, invalid("amount out of range")
+    }
+    return int64(magnitude), nil
+}
+
+// nanosPerUnit is the scale of the nanos field in google.type.Money
+const nanosPerUnit = 1_000_000_000
+
+// ToUnitsNanos splits Money into whole units and nano units in the shape of
+// google.type.Money. Both values carry the sign of the amount.
+func (m *Money) ToUnitsNanos() (units int64, nanos int32) {
+    factor := int64(math.Pow10(m.currency.details().Precision))
+    units = m.amount / factor
+    nanos = int32((m.amount % factor) * (nanosPerUnit / factor))
+    return units, nanos
+}
+
+// FromUnitsNanos creates Money from google.type.Money fields.
+// nanos must lie in (-1e9, 1e9) and share the sign of units when units is non-zero.
+// Nanos with more digits than the currency's precision are rejected rather than rounded.
+func FromUnitsNanos(currencyCode string, units int64, nanos int32) (*Money, error) {
+    currency, err := GetCurrency(currencyCode)
+    if err != nil {
+        return nil, err
+    }
+
+    if nanos <= -nanosPerUnit || nanos >= nanosPerUnit {
+        return nil, &ValidationError{
+            Field:   "nanos",
+            Message: "nanos must be between -999,999,999 and +999,999,999",
+        }
+    }
+    if (units > 0 && nanos < 0) || (units < 0 && nanos > 0) {
+        return nil, &ValidationError{
+            Field:   "nanos",
+            Message: "units and nanos must have the same sign",
+        }
+    }
+
+    factor := int64(math.Pow10(currency.Precision))
+    nanosPerMinor := int64(nanosPerUnit) / factor
+    if int64(nanos)%nanosPerMinor != 0 {
+        return nil, &ValidationError{
+            Field:   "nanos",
+            Message: fmt.Sprintf("%d nanos cannot be represented with %d decimal places for %s without precision loss", nanos, currency.Precision, currency.Code),
+        }
+    }
+
+    if units > math.MaxInt64/factor || units < math.MinInt64/factor {
+        return nil, &OverflowError{
+            Operation: "units conversion",
+            Amount1:   units,
+            Amount2:   factor,
+        }
+    }
+    minor := int64(nanos) / nanosPerMinor
+    scaled := units * factor
+    if (minor > 0 && scaled > math.MaxInt64-minor) || (minor < 0 && scaled < math.MinInt64-minor) {
+        return nil, &OverflowError{
+            Operation: "units conversion",
+            Amount1:   scaled,
+            Amount2:   minor,
+        }
+    }
+
+    return &Money{amount: scaled + minor, currency: internCurrency(currency.Code)}, nil
+}
@@ -0,0 +1,278 @@
+package money
+
+import (
+    "bytes"
+    "encoding/gob"
+    "encoding/xml"
+    "errors"
+    "fmt"
+    "math"
+    "testing"
+)
+
+// mustNew creates Money for tests, failing the test on error
+func mustNew(t *testing.T, amount int64, code string) *Money {
+    t.Helper()
+    m, err := New(amount, code)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return m
+}
+
+func TestMarshalText(t *testing.T) {
+    tests := []struct {
+        amount int64
+        code   string
+        want   string
+    }{
+        {1234, "EUR", "EUR 12.34"},
+        {-5, "USD", "USD -0.05"},
+        {0, "USD", "USD 0.00"},
+        {1500, "JPY", "JPY 1500"},
+        {1, "KWD", "KWD 0.001"},
+        {-9223372036854775808, "USD", "USD -92233720368547758.08"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.want, func(t *testing.T) {
+            text, err := mustNew(t, tt.amount, tt.code).MarshalText()
+            if err != nil {
+                t.Fatal(err)
+            }
+            if string(text) != tt.want {
+                t.Errorf("MarshalText() = %q, want %q", text, tt.want)
+            }
+
+            var decoded Money
+            if err := decoded.UnmarshalText(text); err != nil {
+                t.Fatal(err)
+            }
+            if decoded.Amount() != tt.amount || decoded.Currency().Code != tt.code {
+                t.Errorf("UnmarshalText(%q) = %d %s", text, decoded.Amount(), decoded.Currency().Code)
+            }
+        })
+    }
+}
+
+func TestMarshalTextZeroValue(t *testing.T) {
+    var m Money
+    var validation *ValidationError
+    if _, err := m.MarshalText(); !errors.As(err, &validation) {
+        t.Errorf("MarshalText() of the zero value error = %v, want ValidationError", err)
+    }
+}
+
+func TestUnmarshalText(t *testing.T) {
+    tests := []struct {
+        input   string
+        want    int64
+        wantErr bool
+    }{
+        {"EUR 12.34", 1234, false},
+        {"  EUR   12.34 ", 1234, false},
+        {"EUR 12.3", 1230, false},
+        {"EUR 12", 1200, false},
+        {"EUR .5", 50, false},
+        {"EUR +1.00", 100, false},
+        {"EUR 12.340", 1234, false},
+        {"EUR 12.345", 0, true},
+        {"EUR 1,234.00", 0, true},
+        {"EUR", 0, true},
+        {"EUR 1 2", 0, true},
+        {"XXX 1.00", 0, true},
+        {"EUR -", 0, true},
+        {"EUR 92233720368547758.08", 0, true},
+    }
+    for _, tt := range tests {
+        t.Run(tt.input, func(t *testing.T) {
+            var m Money
+            err := m.UnmarshalText([]byte(tt.input))
+            if (err != nil) != tt.wantErr {
+                t.Fatalf("UnmarshalText(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+            }
+            if err == nil && m.Amount() != tt.want {
+                t.Errorf("UnmarshalText(%q) = %d, want %d", tt.input, m.Amount(), tt.want)
+            }
+        })
+    }
+}
+
+func TestXML(t *testing.T) {
+    type payment struct {
+        XMLName xml.Name `xml:"Pmt"`
+        Amount  Money    `xml:"Amt"`
+    }
+
+    data, err := xml.Marshal(payment{Amount: *mustNew(t, 1234, "EUR")})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if want := `<Pmt><Amt Ccy="EUR">12.34</Amt></Pmt>`; string(data) != want {
+        t.Errorf("xml.Marshal() = %s, want %s", data, want)
+    }
+
+    tests := []struct {
+        name    string
+        input   string
+        want    int64
+        wantErr bool
+    }{
+        {"canonical", `<Pmt><Amt Ccy="EUR">12.34</Amt></Pmt>`, 1234, false},
+        {"surrounding whitespace", `<Pmt><Amt Ccy="JPY"> 1500 </Amt></Pmt>`, 1500, false},
+        {"missing currency", `<Pmt><Amt>12.34</Amt></Pmt>`, 0, true},
+        {"unknown currency", `<Pmt><Amt Ccy="XXX">12.34</Amt></Pmt>`, 0, true},
+        {"too many decimals", `<Pmt><Amt Ccy="EUR">12.345</Amt></Pmt>`, 0, true},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            var p payment
+            err := xml.Unmarshal([]byte(tt.input), &p)
+            if (err != nil) != tt.wantErr {
+                t.Fatalf("xml.Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+            }
+            if err == nil && p.Amount.Amount() != tt.want {
+                t.Errorf("xml.Unmarshal() amount = %d, want %d", p.Amount.Amount(), tt.want)
+            }
+        })
+    }
+}
+
+func TestMarshalBinary(t *testing.T) {
+    tests := []struct {
+        amount int64
+        code   string
+        want   []byte
+    }{
+        {1234, "EUR", []byte{binaryFormatVersion, 3, 'E', 'U', 'R', 0xa4, 0x13}},
+        {0, "USD", []byte{binaryFormatVersion, 3, 'U', 'S', 'D', 0x00}},
+        {-1, "JPY", []byte{binaryFormatVersion, 3, 'J', 'P', 'Y', 0x01}},
+        {math.MaxInt64, "KWD", nil},
+        {math.MinInt64, "KWD", nil},
+    }
+    for _, tt := range tests {
+        t.Run(fmt.Sprintf("%d %s", tt.amount, tt.code), func(t *testing.T) {
+            data, err := mustNew(t, tt.amount, tt.code).MarshalBinary()
+            if err != nil {
+                t.Fatal(err)
+            }
+            if tt.want != nil && !bytes.Equal(data, tt.want) {
+                t.Errorf("MarshalBinary() = %x, want %x", data, tt.want)
+            }
+
+            var decoded Money
+            if err := decoded.UnmarshalBinary(data); err != nil {
+                t.Fatal(err)
+            }
+            if decoded.Amount() != tt.amount || decoded.Currency().Code != tt.code {
+                t.Errorf("UnmarshalBinary(%x) = %d %s", data, decoded.Amount(), decoded.Currency().Code)
+            }
+        })
+    }
+}
+
+func TestUnmarshalBinaryRejects(t *testing.T) {
+    tests := []struct {
+        name string
+        data []byte
+    }{
+        {"empty", nil},
+        {"version zero", []byte{0, 3, 'E', 'U', 'R', 0x00}},
+        {"future version", []byte{binaryFormatVersion + 1, 3, 'E', 'U', 'R', 0x00}},
+        {"missing code length", []byte{binaryFormatVersion}},
+        {"code longer than input", []byte{binaryFormatVersion, 5, 'E', 'U', 'R'}},
+        {"code longer than limit", append([]byte{binaryFormatVersion, maxBinaryCodeLength + 1}, make([]byte, maxBinaryCodeLength+2)...)},
+        {"missing amount", []byte{binaryFormatVersion, 3, 'E', 'U', 'R'}},
+        {"truncated amount", []byte{binaryFormatVersion, 3, 'E', 'U', 'R', 0x80}},
+        {"trailing data", []byte{binaryFormatVersion, 3, 'E', 'U', 'R', 0x00, 0x00}},
+        {"unknown currency", []byte{binaryFormatVersion, 3, 'X', 'X', 'X', 0x00}},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            var m Money
+            var validation *ValidationError
+            if err := m.UnmarshalBinary(tt.data); !errors.As(err, &validation) {
+                t.Errorf("UnmarshalBinary(%x) error = %v, want ValidationError", tt.data, err)
+            }
+        })
+    }
+}
+
+func TestGob(t *testing.T) {
+    type invoice struct {
+        Total Money
+        Lines []Money
+    }
+    in := invoice{
+        Total: *mustNew(t, 3000, "EUR"),
+        Lines: []Money{*mustNew(t, 1000, "EUR"), *mustNew(t, 2000, "EUR")},
+    }
+
+    var buf bytes.Buffer
+    if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+        t.Fatal(err)
+    }
+    var out invoice
+    if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+        t.Fatal(err)
+    }
+    if out.Total != in.Total || len(out.Lines) != 2 || out.Lines[1] != in.Lines[1] {
+        t.Errorf("gob round trip = %+v, want %+v", out, in)
+    }
+}
+
+func TestToUnitsNanos(t *testing.T) {
+    tests := []struct {
+        amount    int64
+        code      string
+        wantUnits int64
+        wantNanos int32
+    }{
+        {1234, "EUR", 12, 340_000_000},
+        {-1234, "EUR", -12, -340_000_000},
+        {-5, "USD", 0, -50_000_000},
+        {1500, "JPY", 1500, 0},
+        {1, "KWD", 0, 1_000_000},
+    }
+    for _, tt := range tests {
+        t.Run(fmt.Sprintf("%d %s", tt.amount, tt.code), func(t *testing.T) {
+            units, nanos := mustNew(t, tt.amount, tt.code).ToUnitsNanos()
+            if units != tt.wantUnits || nanos != tt.wantNanos {
+                t.Errorf("ToUnitsNanos() = (%d, %d), want (%d, %d)", units, nanos, tt.wantUnits, tt.wantNanos)
+            }
+
+            m, err := FromUnitsNanos(tt.code, units, nanos)
+            if err != nil {
+                t.Fatal(err)
+            }
+            if m.Amount() != tt.amount {
+                t.Errorf("FromUnitsNanos(%s, %d, %d) = %d, want %d", tt.code, units, nanos, m.Amount(), tt.amount)
+            }
+        })
+    }
+}
+
+func TestFromUnitsNanosRejects(t *testing.T) {
+    tests := []struct {
+        name  string
+        code  string
+        units int64
+        nanos int32
+    }{
+        {"unknown currency", "XXX", 1, 0},
+        {"nanos too large", "EUR", 0, 1_000_000_000},
+        {"nanos too small", "EUR", 0, -1_000_000_000},
+        {"mixed signs", "EUR", 1, -500_000_000},
+        {"mixed signs negative units", "EUR", -1, 500_000_000},
+        {"sub-cent nanos", "EUR", 0, 5_000_000},
+        {"fractional yen", "JPY", 1, 500_000_000},
+        {"units overflow", "EUR", math.MaxInt64 / 10, 0},
+        {"units and nanos overflow", "EUR", math.MaxInt64 / 100, 990_000_000},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if m, err := FromUnitsNanos(tt.code, tt.units, tt.nanos); err == nil {
+                t.Errorf("FromUnitsNanos(%s, %d, %d) = %d, want an error", tt.code, tt.units, tt.nanos, m.Amount())
+            }
+        })
+    }
+}
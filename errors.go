@@ -3,6 +3,8 @@ package money
 import (
     "fmt"
     "math"
+    "strings"
+    "time"
 )
 
 // CurrencyMismatchError represents an error when operations are attempted between different currencies
@@ -30,12 +32,18 @@ func (e *OverflowError) Error() string {
 type ValidationError struct {
     Field   string
     Message string
+    Err     error // Underlying cause, if any
 }
 
 func (e *ValidationError) Error() string {
     return fmt.Sprintf("validation error for %s: %s", e.Field, e.Message)
 }
 
+// Unwrap returns the underlying cause for errors.Is and errors.As
+func (e *ValidationError) Unwrap() error {
+    return e.Err
+}
+
 // GetCurrencyError represents an error when a currency code is not found
 type GetCurrencyError struct {
     Code string
@@ -55,3 +63,121 @@ func ValidateAmount(amount int64) error {
     }
     return nil
 }
+
+// RateNotFoundError represents an error when no exchange rate is available for a currency pair
+type RateNotFoundError struct {
+    From string
+    To   string
+    Date *time.Time
+}
+
+func (e *RateNotFoundError) Error() string {
+    if e.Date != nil {
+        return fmt.Sprintf("no exchange rate from %s to %s on %s", e.From, e.To, e.Date.Format("2006-01-02"))
+    }
+    return fmt.Sprintf("no exchange rate from %s to %s", e.From, e.To)
+}
+
+// ProviderFailure records why one provider in a ChainConverter did not supply a rate
+type ProviderFailure struct {
+    Provider string
+    Err      error
+}
+
+// ChainError represents an error when a ChainConverter could not obtain an acceptable rate.
+// It lists the failure of every provider that was tried.
+type ChainError struct {
+    From     string
+    To       string
+    Reason   string
+    Failures []ProviderFailure
+}
+
+func (e *ChainError) Error() string {
+    var details strings.Builder
+    for i, failure := range e.Failures {
+        if i > 0 {
+            details.WriteString("; ")
+        }
+        details.WriteString(failure.Provider + ": " + failure.Err.Error())
+    }
+    return fmt.Sprintf("rate from %s to %s: %s [%s]", e.From, e.To, e.Reason, details.String())
+}
+
+// Unwrap returns the individual provider errors for errors.Is and errors.As
+func (e *ChainError) Unwrap() []error {
+    errs := make([]error, len(e.Failures))
+    for i, failure := range e.Failures {
+        errs[i] = failure.Err
+    }
+    return errs
+}
+
+// NoPathError represents an error when no chain of known rates connects two currencies
+type NoPathError struct {
+    From    string
+    To      string
+    MaxLegs int
+}
+
+func (e *NoPathError) Error() string {
+    return fmt.Sprintf("no conversion path from %s to %s within %d legs", e.From, e.To, e.MaxLegs)
+}
+
+// LineError represents an invalid entry in a rate file, identified by its line number
+type LineError struct {
+    Line int
+    Err  error
+}
+
+func (e *LineError) Error() string {
+    return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+// Unwrap returns the underlying error for errors.Is and errors.As
+func (e *LineError) Unwrap() error {
+    return e.Err
+}
+
+// RateFileError represents a rate file that could not be loaded.
+// It lists every invalid entry that was found.
+type RateFileError struct {
+    Errors []*LineError
+}
+
+func (e *RateFileError) Error() string {
+    var details strings.Builder
+    for i, lineErr := range e.Errors {
+        if i > 0 {
+            details.WriteString("; ")
+        }
+        details.WriteString(lineErr.Error())
+    }
+    return fmt.Sprintf("invalid rate file: %s", details.String())
+}
+
+// Unwrap returns the individual line errors for errors.Is and errors.As
+func (e *RateFileError) Unwrap() []error {
+    errs := make([]error, len(e.Errors))
+    for i, lineErr := range e.Errors {
+        errs[i] = lineErr
+    }
+    return errs
+}
+
+// NoExactSourceError represents an error when no source amount converts to exactly
+// the requested target. Closest is the smallest source amount that reaches the target,
+// and Result is what it converts to.
+type NoExactSourceError struct {
+    Target  *Money
+    Closest *Money
+    Result  *Money
+}
+
+func (e *NoExactSourceError) Error() string {
+    target, _ := e.Target.MarshalText()
+    closest, _ := e.Closest.MarshalText()
+    result, _ := e.Result.MarshalText()
+    return fmt.Sprintf("no %s amount converts to exactly %s; closest is %s giving %s",
+        e.Closest.CurrencyCode(), target, closest, result)
+}
@@ -7,35 +7,18 @@ import (
 	"github.com/ha1tch/money"
 )
 
-// Example currency converter implementation
-type ExampleConverter struct{}
-
-func (c *ExampleConverter) GetRate(from, to string, date *time.Time) (float64, error) {
-	// Example fixed rates (in real usage, you'd fetch these from an API)
-	rates := map[string]map[string]float64{
-		"USD": {
-			"EUR": 0.85,
-			"GBP": 0.73,
-			"JPY": 110.0,
-		},
-		"EUR": {
-			"USD": 1.18,
-			"GBP": 0.86,
-			"JPY": 129.5,
-		},
-	}
-
-	if fromRates, ok := rates[from]; ok {
-		if rate, ok := fromRates[to]; ok {
-			return rate, nil
-		}
-	}
-	return 0, fmt.Errorf("rate not found for %s to %s", from, to)
-}
-
 func main() {
-	// Set up the converter
-	money.DefaultConverter = &ExampleConverter{}
+	// Set up the converter with example fixed rates
+	// (in real usage, you'd load these from an API or rate file)
+	rates := money.NewRateTable()
+	effective := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	rates.Set("USD", "EUR", 0.85, effective)
+	rates.Set("USD", "GBP", 0.73, effective)
+	rates.Set("USD", "JPY", 110.0, effective)
+	rates.Set("EUR", "USD", 1.18, effective)
+	rates.Set("EUR", "GBP", 0.86, effective)
+	rates.Set("EUR", "JPY", 129.5, effective)
+	money.DefaultConverter = rates
 
 	// Example 1: Create amounts in different currencies
 	usd, _ := money.NewFromFloat(100.00, "USD")
@@ -0,0 +1,240 @@
+package money
+
+import (
+    "fmt"
+    "math"
+    "math/big"
+)
+
+// Quote is a two-way price for a currency pair, in units of To per unit of From.
+// The dealer buys From from customers at Bid and sells it to them at Ask.
+type Quote struct {
+    From string
+    To   string
+    Bid  Rate
+    Ask  Rate
+}
+
+// NewQuote creates a Quote, validating the currencies and that 0 < bid <= ask
+func NewQuote(from, to string, bid, ask Rate) (Quote, error) {
+    if _, err := GetCurrency(from); err != nil {
+        return Quote{}, err
+    }
+    if _, err := GetCurrency(to); err != nil {
+        return Quote{}, err
+    }
+    if !bid.IsPositive() || bid.Cmp(ask) > 0 {
+        return Quote{}, &ValidationError{
+            Field:   "quote",
+            Message: fmt.Sprintf("quote for %s/%s must have 0 < bid <= ask, got %s/%s", from, to, bid, ask),
+        }
+    }
+    return Quote{From: from, To: to, Bid: bid, Ask: ask}, nil
+}
+
+// QuoteFromMid creates a Quote around a mid rate with a total relative spread,
+// so that a spread of 0.02 quotes 1% either side of mid
+func QuoteFromMid(from, to string, mid, spread Rate) (Quote, error) {
+    half := new(big.Rat).Quo(spread.rat(), big.NewRat(2, 1))
+    bid := new(big.Rat).Sub(big.NewRat(1, 1), half)
+    ask := new(big.Rat).Add(big.NewRat(1, 1), half)
+    return NewQuote(from, to, mid.Mul(Rate{r: bid}), mid.Mul(Rate{r: ask}))
+}
+
+// Mid returns the rate halfway between Bid and Ask
+func (q Quote) Mid() Rate {
+    mid := new(big.Rat).Add(q.Bid.rat(), q.Ask.rat())
+    return Rate{r: mid.Quo(mid, big.NewRat(2, 1))}
+}
+
+// Spread returns Ask minus Bid
+func (q Quote) Spread() Rate {
+    return Rate{r: new(big.Rat).Sub(q.Ask.rat(), q.Bid.rat())}
+}
+
+// Side is the direction of an exchange from the customer's point of view
+type Side int
+
+const (
+    CustomerSells Side = iota // The customer hands over From and receives To at Bid
+    CustomerBuys              // The customer hands over To and receives From at 1/Ask
+)
+
+// FeeSchedule describes the charges applied to an exchange, in the currency received
+type FeeSchedule struct {
+    Percent Rate   // Commission as a fraction of the converted amount, such as 0.015 for 1.5%
+    Fixed   *Money // Flat fee in the currency received; nil for none
+}
+
+// Fee returns the fee charged on an amount converted at the customer rate.
+// The percentage is rounded with DefaultRoundingMethod before the fixed fee is added.
+func (f *FeeSchedule) Fee(converted *Money) (*Money, error) {
+    fee := Money{currency: converted.currency}
+    if f == nil {
+        return &fee, nil
+    }
+
+    if f.Percent.Sign() != 0 {
+        commission := new(big.Rat).SetInt64(converted.amount)
+        amount, err := roundRat(commission.Mul(commission, f.Percent.rat()), DefaultRoundingMethod)
+        if err != nil {
+            return nil, err
+        }
+        fee.amount = amount
+    }
+    if f.Fixed != nil {
+        var err error
+        if fee, err = fee.AddValue(*f.Fixed); err != nil {
+            return nil, err
+        }
+    }
+    return &fee, nil
+}
+
+// ExchangeResult itemizes an exchange. Gross is the amount at the mid rate;
+// Gross = Net + RateMargin + Fee.
+type ExchangeResult struct {
+    Source     *Money
+    Side       Side
+    Quote      Quote
+    Rate       Rate   // Customer rate applied, in units received per unit handed over
+    Gross      *Money // Converted at the mid rate
+    RateMargin *Money // Gross minus the amount converted at the customer rate
+    Fee        *Money // Charges from the FeeSchedule
+    Net        *Money // Amount the customer receives
+}
+
+// Exchange converts Money at the customer side of a quote and applies fees. When the
+// customer sells, the Money must be in the quote's From currency; when the customer
+// buys, it must be in its To currency. Amounts are computed exactly with ConvertToRate
+// and rounded once each with DefaultRoundingMethod.
+func (m *Money) Exchange(quote Quote, side Side, fees *FeeSchedule) (*ExchangeResult, error) {
+    pay, receive := quote.From, quote.To
+    rate, mid := quote.Bid, quote.Mid()
+    if side == CustomerBuys {
+        pay, receive = quote.To, quote.From
+        rate, mid = quote.Ask.Inverse(), mid.Inverse()
+    }
+    if m.currency.code() != pay {
+        return nil, &CurrencyMismatchError{
+            Currency1: m.currency.code(),
+            Currency2: pay,
+        }
+    }
+
+    gross, err := m.ConvertToRate(receive, mid)
+    if err != nil {
+        return nil, err
+    }
+    converted, err := m.ConvertToRate(receive, rate)
+    if err != nil {
+        return nil, err
+    }
+    margin, err := gross.Subtract(converted)
+    if err != nil {
+        return nil, err
+    }
+    fee, err := fees.Fee(converted)
+    if err != nil {
+        return nil, err
+    }
+    net, err := converted.Subtract(fee)
+    if err != nil {
+        return nil, err
+    }
+    if net.IsNegative() {
+        return nil, &ValidationError{
+            Field:   "fees",
+            Message: fmt.Sprintf("fees of %s exceed the converted amount of %s", fee.Format(), converted.Format()),
+        }
+    }
+
+    return &ExchangeResult{
+        Source:     m,
+        Side:       side,
+        Quote:      quote,
+        Rate:       rate,
+        Gross:      gross,
+        RateMargin: margin,
+        Fee:        fee,
+        Net:        net,
+    }, nil
+}
+
+// SourceAmountFor returns the smallest amount in sourceCode whose forward conversion at
+// rate, as computed by ConvertToRate less the fees, is exactly target. Because rounding
+// can skip values, no exact amount may exist; a *NoExactSourceError then reports the
+// smallest amount that reaches the target.
+func SourceAmountFor(target *Money, sourceCode string, rate Rate, fees *FeeSchedule) (*Money, error) {
+    source, err := NewValue(0, sourceCode)
+    if err != nil {
+        return nil, err
+    }
+    if !rate.IsPositive() {
+        return nil, &ValidationError{
+            Field:   "rate",
+            Message: fmt.Sprintf("rate from %s to %s must be positive", sourceCode, target.CurrencyCode()),
+        }
+    }
+    if target.IsNegative() {
+        return nil, &ValidationError{
+            Field:   "target",
+            Message: "target amount must not be negative",
+        }
+    }
+
+    forward := func(amount int64) (*Money, error) {
+        source.amount = amount
+        converted, err := source.ConvertToRate(target.CurrencyCode(), rate)
+        if err != nil {
+            return nil, err
+        }
+        fee, err := fees.Fee(converted)
+        if err != nil {
+            return nil, err
+        }
+        return converted.Subtract(fee)
+    }
+
+    // Grow the upper bound until it reaches the target, then search below it
+    low, high := int64(0), int64(1)
+    for {
+        result, err := forward(high)
+        if err != nil {
+            return nil, err
+        }
+        if result.amount >= target.amount {
+            break
+        }
+        if high > math.MaxInt64/2 {
+            return nil, &OverflowError{
+                Operation: "inverse conversion",
+                Amount1:   target.amount,
+                Amount2:   high,
+            }
+        }
+        low, high = high, high*2
+    }
+    for low < high {
+        mid := low + (high-low)/2
+        result, err := forward(mid)
+        if err != nil {
+            return nil, err
+        }
+        if result.amount >= target.amount {
+            high = mid
+        } else {
+            low = mid + 1
+        }
+    }
+
+    result, err := forward(high)
+    if err != nil {
+        return nil, err
+    }
+    closest := &Money{amount: high, currency: source.currency}
+    if result.amount != target.amount {
+        return nil, &NoExactSourceError{Target: target, Closest: closest, Result: result}
+    }
+    return closest, nil
+}
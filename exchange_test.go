@@ -0,0 +1,257 @@
+package money
+
+import (
+    "errors"
+    "testing"
+)
+
+func TestNewQuote(t *testing.T) {
+    tests := []struct {
+        name     string
+        from, to string
+        bid, ask string
+        wantErr  bool
+    }{
+        {"valid", "EUR", "USD", "1.08", "1.10", false},
+        {"zero spread", "EUR", "USD", "1.09", "1.09", false},
+        {"bid above ask", "EUR", "USD", "1.10", "1.08", true},
+        {"zero bid", "EUR", "USD", "0", "1.10", true},
+        {"unknown currency", "EUR", "XYZ", "1.08", "1.10", true},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            quote, err := NewQuote(tt.from, tt.to, MustParseRate(tt.bid), MustParseRate(tt.ask))
+            if (err != nil) != tt.wantErr {
+                t.Fatalf("NewQuote() error = %v, wantErr %v", err, tt.wantErr)
+            }
+            if err == nil && (quote.From != tt.from || quote.Bid.String() != tt.bid) {
+                t.Errorf("NewQuote() = %+v", quote)
+            }
+        })
+    }
+}
+
+func TestQuoteFromMid(t *testing.T) {
+    quote, err := QuoteFromMid("EUR", "USD", MustParseRate("1.09"), MustParseRate("0.02"))
+    if err != nil {
+        t.Fatal(err)
+    }
+    if quote.Bid.Cmp(MustParseRate("1.0791")) != 0 || quote.Ask.Cmp(MustParseRate("1.1009")) != 0 {
+        t.Errorf("QuoteFromMid() = %v/%v, want 1.0791/1.1009", quote.Bid, quote.Ask)
+    }
+    if quote.Mid().Cmp(MustParseRate("1.09")) != 0 || quote.Spread().Cmp(MustParseRate("0.0218")) != 0 {
+        t.Errorf("Mid() = %v, Spread() = %v", quote.Mid(), quote.Spread())
+    }
+}
+
+func TestFeeSchedule(t *testing.T) {
+    tests := []struct {
+        name      string
+        fees      *FeeSchedule
+        converted int64
+        want      int64
+    }{
+        {"nil schedule", nil, 12345, 0},
+        {"percentage rounded", &FeeSchedule{Percent: MustParseRate("0.015")}, 12345, 185},
+        {"percentage rounded half up", &FeeSchedule{Percent: MustParseRate("0.01")}, 150, 2},
+        {"fixed", &FeeSchedule{Fixed: &Money{amount: 200, currency: internCurrency("USD")}}, 12345, 200},
+        {"percentage and fixed", &FeeSchedule{Percent: MustParseRate("0.015"), Fixed: &Money{amount: 200, currency: internCurrency("USD")}}, 12345, 385},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            fee, err := tt.fees.Fee(mustNew(t, tt.converted, "USD"))
+            if err != nil {
+                t.Fatal(err)
+            }
+            if fee.Amount() != tt.want || fee.CurrencyCode() != "USD" {
+                t.Errorf("Fee() = %v, want %d USD", fee, tt.want)
+            }
+        })
+    }
+
+    fixed := &FeeSchedule{Fixed: mustNew(t, 200, "EUR")}
+    var mismatch *CurrencyMismatchError
+    if _, err := fixed.Fee(mustNew(t, 12345, "USD")); !errors.As(err, &mismatch) {
+        t.Errorf("Fee() with a fixed fee in another currency error = %v, want CurrencyMismatchError", err)
+    }
+}
+
+func TestExchange(t *testing.T) {
+    quote, err := NewQuote("EUR", "USD", MustParseRate("1.08"), MustParseRate("1.10"))
+    if err != nil {
+        t.Fatal(err)
+    }
+    commission := &FeeSchedule{Percent: MustParseRate("0.015"), Fixed: mustNew(t, 200, "USD")}
+
+    tests := []struct {
+        name                    string
+        source                  *Money
+        side                    Side
+        fees                    *FeeSchedule
+        wantRate                Rate
+        gross, margin, fee, net int64
+        wantCurrency            string
+    }{
+        {
+            name:   "customer sells at bid",
+            source: mustNew(t, 100000, "EUR"), side: CustomerSells,
+            wantRate: MustParseRate("1.08"),
+            gross:    109000, margin: 1000, fee: 0, net: 108000, wantCurrency: "USD",
+        },
+        {
+            name:   "customer sells with fees",
+            source: mustNew(t, 100000, "EUR"), side: CustomerSells, fees: commission,
+            wantRate: MustParseRate("1.08"),
+            gross:    109000, margin: 1000, fee: 1820, net: 106180, wantCurrency: "USD",
+        },
+        {
+            name:   "customer buys at ask",
+            source: mustNew(t, 110000, "USD"), side: CustomerBuys,
+            wantRate: MustParseRate("1.10").Inverse(),
+            gross:    100917, margin: 917, fee: 0, net: 100000, wantCurrency: "EUR",
+        },
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            result, err := tt.source.Exchange(quote, tt.side, tt.fees)
+            if err != nil {
+                t.Fatal(err)
+            }
+            if result.Rate.Cmp(tt.wantRate) != 0 {
+                t.Errorf("Rate = %v, want %v", result.Rate, tt.wantRate)
+            }
+            got := [4]int64{result.Gross.Amount(), result.RateMargin.Amount(), result.Fee.Amount(), result.Net.Amount()}
+            if got != [4]int64{tt.gross, tt.margin, tt.fee, tt.net} {
+                t.Errorf("gross, margin, fee, net = %v, want %v", got, [4]int64{tt.gross, tt.margin, tt.fee, tt.net})
+            }
+            if result.Gross.Amount() != result.Net.Amount()+result.RateMargin.Amount()+result.Fee.Amount() {
+                t.Error("Gross is not Net + RateMargin + Fee")
+            }
+            if result.Net.CurrencyCode() != tt.wantCurrency || result.Source != tt.source || result.Side != tt.side {
+                t.Errorf("result = %+v", result)
+            }
+        })
+    }
+}
+
+func TestExchangeErrors(t *testing.T) {
+    quote, err := NewQuote("EUR", "USD", MustParseRate("1.08"), MustParseRate("1.10"))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    var mismatch *CurrencyMismatchError
+    if _, err := mustNew(t, 100000, "EUR").Exchange(quote, CustomerBuys, nil); !errors.As(err, &mismatch) {
+        t.Errorf("Exchange() paying in the wrong currency error = %v, want CurrencyMismatchError", err)
+    }
+
+    var validation *ValidationError
+    fees := &FeeSchedule{Fixed: mustNew(t, 500, "USD")}
+    if _, err := mustNew(t, 100, "EUR").Exchange(quote, CustomerSells, fees); !errors.As(err, &validation) || validation.Field != "fees" {
+        t.Errorf("Exchange() with fees above the amount error = %v, want fees ValidationError", err)
+    }
+}
+
+func TestSourceAmountFor(t *testing.T) {
+    tests := []struct {
+        name        string
+        target      *Money
+        source      string
+        rate        string
+        fees        *FeeSchedule
+        want        int64
+        wantResult  int64 // What the closest amount converts to when no exact amount exists
+        wantNoExact bool
+    }{
+        {"exact", mustNew(t, 10800, "USD"), "EUR", "1.08", nil, 10000, 0, false},
+        {"zero target", mustNew(t, 0, "USD"), "EUR", "1.08", nil, 0, 0, false},
+        {"smallest of several sources", mustNew(t, 1, "USD"), "EUR", "0.3", nil, 2, 0, false},
+        {"rounding gap", mustNew(t, 100, "EUR"), "USD", "3", nil, 34, 102, true},
+        {"to a currency without minor units", mustNew(t, 300, "JPY"), "USD", "150", nil, 200, 0, false},
+        {"rounding gap without minor units", mustNew(t, 1, "JPY"), "USD", "150", nil, 1, 2, true},
+        {"fixed fee", mustNew(t, 10600, "USD"), "EUR", "1.08", &FeeSchedule{Fixed: mustNew(t, 200, "USD")}, 10000, 0, false},
+        {"percentage and fixed fee", mustNew(t, 10492, "USD"), "EUR", "1.08", &FeeSchedule{Percent: MustParseRate("0.01"), Fixed: mustNew(t, 200, "USD")}, 10000, 0, false},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := SourceAmountFor(tt.target, tt.source, MustParseRate(tt.rate), tt.fees)
+            if tt.wantNoExact {
+                var noExact *NoExactSourceError
+                if !errors.As(err, &noExact) {
+                    t.Fatalf("SourceAmountFor() error = %v, want NoExactSourceError", err)
+                }
+                if noExact.Closest.Amount() != tt.want || noExact.Result.Amount() != tt.wantResult || noExact.Target != tt.target {
+                    t.Errorf("NoExactSourceError = %v", noExact)
+                }
+                return
+            }
+            if err != nil {
+                t.Fatal(err)
+            }
+            if got.Amount() != tt.want || got.CurrencyCode() != tt.source {
+                t.Errorf("SourceAmountFor() = %v, want %d %s", got, tt.want, tt.source)
+            }
+        })
+    }
+}
+
+func TestSourceAmountForRoundTrip(t *testing.T) {
+    rate := MustParseRate("1.0837")
+    fees := &FeeSchedule{Percent: MustParseRate("0.015"), Fixed: mustNew(t, 50, "USD")}
+    forward := func(amount int64) int64 {
+        converted, err := mustNew(t, amount, "EUR").ConvertToRate("USD", rate)
+        if err != nil {
+            t.Fatal(err)
+        }
+        fee, err := fees.Fee(converted)
+        if err != nil {
+            t.Fatal(err)
+        }
+        return converted.Amount() - fee.Amount()
+    }
+
+    for target := int64(0); target <= 2000; target++ {
+        got, err := SourceAmountFor(mustNew(t, target, "USD"), "EUR", rate, fees)
+        var noExact *NoExactSourceError
+        switch {
+        case err == nil:
+            if forward(got.Amount()) != target || (got.Amount() > 0 && forward(got.Amount()-1) == target) {
+                t.Fatalf("SourceAmountFor(%d) = %d, not the smallest exact source", target, got.Amount())
+            }
+        case errors.As(err, &noExact):
+            closest := noExact.Closest.Amount()
+            if forward(closest) <= target || (closest > 0 && forward(closest-1) >= target) {
+                t.Fatalf("SourceAmountFor(%d) closest = %d, not the smallest amount past the target", target, closest)
+            }
+        default:
+            t.Fatal(err)
+        }
+    }
+}
+
+func TestSourceAmountForErrors(t *testing.T) {
+    tests := []struct {
+        name      string
+        target    *Money
+        source    string
+        rate      string
+        wantField string // Expected ValidationError field, or empty for another error
+    }{
+        {"zero rate", mustNew(t, 100, "USD"), "EUR", "0", "rate"},
+        {"negative rate", mustNew(t, 100, "USD"), "EUR", "-1.08", "rate"},
+        {"negative target", mustNew(t, -100, "USD"), "EUR", "1.08", "target"},
+        {"unknown source currency", mustNew(t, 100, "USD"), "XYZ", "1.08", ""},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            _, err := SourceAmountFor(tt.target, tt.source, MustParseRate(tt.rate), nil)
+            if err == nil {
+                t.Fatal("SourceAmountFor() succeeded")
+            }
+            var validation *ValidationError
+            if tt.wantField != "" && (!errors.As(err, &validation) || validation.Field != tt.wantField) {
+                t.Errorf("SourceAmountFor() error = %v, want %s ValidationError", err, tt.wantField)
+            }
+        })
+    }
+}
@@ -1,5 +1,10 @@
 package money
 
+import (
+	"fmt"
+	"math/big"
+)
+
 // Helper function for rounding monetary values.
 // The value parameter is the amount to round multiplied by 10 to handle an extra decimal place during calculations.
 // For example, to round 1.234, pass 12340. The function will return 123 (representing 1.23).
@@ -140,3 +145,135 @@ func formatBrazilianAmount(amount int64) int64 {
 
 	return wholePart + roundedCents
 }
+
+// divRound divides value by a positive divisor and rounds the quotient with the given method.
+// Rounding is applied to the magnitude, so RoundUp and RoundHalfUp move away from zero
+// for negative values as well. BrazilianRounding only applies to final BRL amounts and
+// is treated as RoundHalfUp here.
+func divRound(value, divisor int64, method RoundingMethod) int64 {
+	quotient := value / divisor
+	remainder := value % divisor
+	if remainder == 0 {
+		return quotient
+	}
+
+	step := int64(1)
+	if value < 0 {
+		step = -1
+		remainder = -remainder
+	}
+
+	// Compare the remainder with half the divisor without overflowing
+	half := divisor - remainder
+	switch method {
+	case RoundDown:
+		return quotient
+	case RoundUp:
+		return quotient + step
+	case RoundHalfDown:
+		if remainder > half {
+			return quotient + step
+		}
+	case RoundHalfEven:
+		if remainder > half || (remainder == half && quotient%2 != 0) {
+			return quotient + step
+		}
+	default:
+		if remainder >= half {
+			return quotient + step
+		}
+	}
+	return quotient
+}
+
+// roundRat rounds an exact value to an integer with the given method, using the same
+// magnitude-based semantics as divRound. It reports an OverflowError if the result
+// does not fit in an int64.
+func roundRat(x *big.Rat, method RoundingMethod) (int64, error) {
+	quotient := roundRatInt(x, method)
+	if !quotient.IsInt64() {
+		return 0, &OverflowError{
+			Operation: "rounding",
+			Amount1:   x.Num().Int64(),
+			Amount2:   x.Denom().Int64(),
+		}
+	}
+	return quotient.Int64(), nil
+}
+
+// roundRatInt rounds an exact value to an arbitrary-precision integer with the given method
+func roundRatInt(x *big.Rat, method RoundingMethod) *big.Int {
+	num, den := x.Num(), x.Denom()
+	quotient, remainder := new(big.Int).QuoRem(num, den, new(big.Int))
+
+	if remainder.Sign() != 0 {
+		step := big.NewInt(int64(num.Sign()))
+		twice := new(big.Int).Abs(remainder)
+		twice.Lsh(twice, 1)
+		cmp := twice.Cmp(den)
+
+		switch method {
+		case RoundDown:
+		case RoundUp:
+			quotient.Add(quotient, step)
+		case RoundHalfDown:
+			if cmp > 0 {
+				quotient.Add(quotient, step)
+			}
+		case RoundHalfEven:
+			if cmp > 0 || (cmp == 0 && quotient.Bit(0) == 1) {
+				quotient.Add(quotient, step)
+			}
+		default:
+			if cmp >= 0 {
+				quotient.Add(quotient, step)
+			}
+		}
+	}
+
+	return quotient
+}
+
+// abs returns the absolute value of an int
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+var roundingMethodNames = map[RoundingMethod]string{
+	RoundHalfUp:       "RoundHalfUp",
+	RoundHalfDown:     "RoundHalfDown",
+	RoundHalfEven:     "RoundHalfEven",
+	RoundUp:           "RoundUp",
+	RoundDown:         "RoundDown",
+	BrazilianRounding: "BrazilianRounding",
+}
+
+// String returns the name of the rounding method
+func (r RoundingMethod) String() string {
+	if name, ok := roundingMethodNames[r]; ok {
+		return name
+	}
+	return fmt.Sprintf("RoundingMethod(%d)", int(r))
+}
+
+// MarshalText implements encoding.TextMarshaler using the method name
+func (r RoundingMethod) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for method names
+func (r *RoundingMethod) UnmarshalText(text []byte) error {
+	for method, name := range roundingMethodNames {
+		if name == string(text) {
+			*r = method
+			return nil
+		}
+	}
+	return &ValidationError{
+		Field:   "rounding method",
+		Message: fmt.Sprintf("unknown rounding method %q", string(text)),
+	}
+}
@@ -0,0 +1,459 @@
+package money
+
+import (
+    "bytes"
+    "encoding/csv"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "strings"
+    "time"
+)
+
+// CSVRateSchema describes the layout of a CSV rate file with a header row.
+// Columns are located by their header names, in any order.
+type CSVRateSchema struct {
+    DateColumn string // Header of the effective date column
+    FromColumn string // Header of the base currency column
+    ToColumn   string // Header of the quote currency column
+    RateColumn string // Header of the rate column, quoted as units of To per unit of From
+    DateLayout string // time.Parse layout of the dates
+    Comma      rune   // Field delimiter
+    Provider   string // Reported as the provider of the rates read
+}
+
+// DefaultCSVRateSchema reads files with the columns date, base, quote and rate
+var DefaultCSVRateSchema = CSVRateSchema{
+    DateColumn: "date",
+    FromColumn: "base",
+    ToColumn:   "quote",
+    RateColumn: "rate",
+    DateLayout: "2006-01-02",
+    Comma:      ',',
+    Provider:   "CSV",
+}
+
+// JSONRateSchema describes rate snapshots of the form
+// {"date": "2024-01-02", "base": "USD", "rates": {"EUR": "0.91", "JPY": 142.5}}.
+// A file holds either a single snapshot or an array of them. Rates may be
+// written as JSON numbers or strings; both are read exactly.
+type JSONRateSchema struct {
+    DateKey    string // Key of the effective date
+    BaseKey    string // Key of the base currency
+    RatesKey   string // Key of the object mapping quote currencies to rates
+    DateLayout string // time.Parse layout of the dates
+    Provider   string // Reported as the provider of the rates read
+}
+
+// DefaultJSONRateSchema reads snapshots with the keys date, base and rates
+var DefaultJSONRateSchema = JSONRateSchema{
+    DateKey:    "date",
+    BaseKey:    "base",
+    RatesKey:   "rates",
+    DateLayout: "2006-01-02",
+    Provider:   "JSON",
+}
+
+// ReadCSVRates parses a CSV rate file. Every invalid row is reported in a
+// *RateFileError with its line number, and no rates are returned in that case.
+func ReadCSVRates(r io.Reader, schema CSVRateSchema) ([]RateInfo, error) {
+    reader := csv.NewReader(r)
+    if schema.Comma != 0 {
+        reader.Comma = schema.Comma
+    }
+    reader.FieldsPerRecord = -1
+    reader.TrimLeadingSpace = true
+
+    header, err := reader.Read()
+    if err != nil {
+        return nil, csvReadError(err, 1)
+    }
+    columns := make(map[string]int, len(header))
+    for i, name := range header {
+        columns[strings.TrimSpace(name)] = i
+    }
+    var index [4]int
+    for i, name := range []string{schema.DateColumn, schema.FromColumn, schema.ToColumn, schema.RateColumn} {
+        column, ok := columns[name]
+        if !ok {
+            return nil, &RateFileError{Errors: []*LineError{{
+                Line: 1,
+                Err:  &ValidationError{Field: "header", Message: fmt.Sprintf("missing column %q", name)},
+            }}}
+        }
+        index[i] = column
+    }
+
+    var result []RateInfo
+    var failures []*LineError
+    for {
+        record, err := reader.Read()
+        if err == io.EOF {
+            break
+        }
+        if err != nil {
+            return nil, csvReadError(err, 0)
+        }
+        line, _ := reader.FieldPos(0)
+
+        field := func(i int) string {
+            if index[i] < len(record) {
+                return strings.TrimSpace(record[index[i]])
+            }
+            return ""
+        }
+        info, err := parseRateEntry(field(0), field(1), field(2), field(3), schema.DateLayout, schema.Provider)
+        if err != nil {
+            failures = append(failures, &LineError{Line: line, Err: err})
+            continue
+        }
+        result = append(result, info)
+    }
+
+    if len(failures) > 0 {
+        return nil, &RateFileError{Errors: failures}
+    }
+    return result, nil
+}
+
+// csvReadError converts a CSV syntax error into a *RateFileError
+func csvReadError(err error, line int) error {
+    var parseErr *csv.ParseError
+    if errors.As(err, &parseErr) {
+        line = parseErr.Line
+        err = parseErr.Err
+    }
+    return &RateFileError{Errors: []*LineError{{
+        Line: line,
+        Err:  &ValidationError{Field: "csv", Message: err.Error()},
+    }}}
+}
+
+// ReadJSONRates parses a JSON rate snapshot or an array of snapshots. Every invalid
+// entry is reported in a *RateFileError with its line number, and no rates are
+// returned in that case.
+func ReadJSONRates(r io.Reader, schema JSONRateSchema) ([]RateInfo, error) {
+    data, err := io.ReadAll(r)
+    if err != nil {
+        return nil, err
+    }
+    parser := &jsonRateParser{data: data, schema: schema}
+    parser.decoder = json.NewDecoder(bytes.NewReader(data))
+    parser.decoder.UseNumber()
+
+    if err := parser.parse(); err != nil {
+        return nil, &RateFileError{Errors: []*LineError{{
+            Line: parser.line(),
+            Err:  &ValidationError{Field: "json", Message: err.Error()},
+        }}}
+    }
+    if len(parser.failures) > 0 {
+        return nil, &RateFileError{Errors: parser.failures}
+    }
+    return parser.result, nil
+}
+
+// jsonRateParser walks the JSON tokens so that each entry can be reported with its line
+type jsonRateParser struct {
+    data     []byte
+    decoder  *json.Decoder
+    schema   JSONRateSchema
+    result   []RateInfo
+    failures []*LineError
+}
+
+// line returns the line of the decoder's current position
+func (p *jsonRateParser) line() int {
+    return bytes.Count(p.data[:p.decoder.InputOffset()], []byte("\n")) + 1
+}
+
+func (p *jsonRateParser) parse() error {
+    token, err := p.decoder.Token()
+    if err != nil {
+        return err
+    }
+    switch token {
+    case json.Delim('{'):
+        err = p.parseSnapshot()
+    case json.Delim('['):
+        for err == nil && p.decoder.More() {
+            if token, err = p.decoder.Token(); err != nil {
+                return err
+            }
+            if token != json.Delim('{') {
+                return fmt.Errorf("expected a rate snapshot object")
+            }
+            err = p.parseSnapshot()
+        }
+        if err == nil {
+            _, err = p.decoder.Token()
+        }
+    default:
+        return fmt.Errorf("expected a rate snapshot object or array")
+    }
+    if err != nil {
+        return err
+    }
+    if _, err := p.decoder.Token(); err != io.EOF {
+        return fmt.Errorf("unexpected data after the rate snapshots")
+    }
+    return nil
+}
+
+// parseSnapshot reads the members of a snapshot whose opening brace has been consumed
+func (p *jsonRateParser) parseSnapshot() error {
+    type quote struct {
+        line     int
+        currency string
+        rate     string
+    }
+    var effective time.Time
+    var base string
+    var quotes []quote
+    snapshotLine := p.line()
+    entries, failures := 0, len(p.failures)
+    dateSeen, baseSeen, headerOK := false, false, true
+
+    for p.decoder.More() {
+        token, err := p.decoder.Token()
+        if err != nil {
+            return err
+        }
+        key, ok := token.(string)
+        if !ok {
+            return fmt.Errorf("expected an object key")
+        }
+        keyLine := p.line()
+
+        switch key {
+        case p.schema.DateKey, p.schema.BaseKey:
+            value, ok, err := p.stringValue()
+            if err != nil {
+                return err
+            }
+            if key == p.schema.DateKey {
+                dateSeen = true
+            } else {
+                baseSeen = true
+            }
+            switch {
+            case !ok:
+                p.fail(keyLine, key, fmt.Sprintf("%q must be a string", key))
+                headerOK = false
+            case key == p.schema.DateKey:
+                if effective, err = parseRateDate(value, p.schema.DateLayout); err != nil {
+                    p.failures = append(p.failures, &LineError{Line: keyLine, Err: err})
+                    headerOK = false
+                }
+            default:
+                base = value
+                if err := checkRateCurrency(base); err != nil {
+                    p.failures = append(p.failures, &LineError{Line: keyLine, Err: err})
+                    headerOK = false
+                }
+            }
+        case p.schema.RatesKey:
+            if token, err = p.decoder.Token(); err != nil {
+                return err
+            }
+            if token != json.Delim('{') {
+                if err := p.skipRest(token); err != nil {
+                    return err
+                }
+                p.fail(keyLine, key, fmt.Sprintf("%q must be an object", key))
+                continue
+            }
+            for p.decoder.More() {
+                if token, err = p.decoder.Token(); err != nil {
+                    return err
+                }
+                currency, ok := token.(string)
+                if !ok {
+                    return fmt.Errorf("expected an object key")
+                }
+                entries++
+                q := quote{line: p.line(), currency: currency}
+                if q.rate, ok, err = p.stringValue(); err != nil {
+                    return err
+                }
+                if !ok {
+                    p.fail(q.line, "rate", fmt.Sprintf("rate for %s must be a number or string", currency))
+                    continue
+                }
+                quotes = append(quotes, q)
+            }
+            if _, err = p.decoder.Token(); err != nil {
+                return err
+            }
+        default:
+            if err := p.skipValue(); err != nil {
+                return err
+            }
+        }
+    }
+    if _, err := p.decoder.Token(); err != nil {
+        return err
+    }
+
+    if !dateSeen {
+        p.fail(snapshotLine, p.schema.DateKey, "snapshot has no date")
+        headerOK = false
+    }
+    if !baseSeen {
+        p.fail(snapshotLine, p.schema.BaseKey, "snapshot has no base currency")
+        headerOK = false
+    }
+    if entries == 0 && len(p.failures) == failures {
+        p.fail(snapshotLine, p.schema.RatesKey, "snapshot contains no rates")
+    }
+    if !headerOK {
+        // Already reported once for the snapshot rather than for every rate
+        return nil
+    }
+    for _, q := range quotes {
+        err := checkRateCurrency(q.currency)
+        var rate Rate
+        if err == nil {
+            rate, err = parseRateValue(q.rate, base, q.currency)
+        }
+        if err != nil {
+            p.failures = append(p.failures, &LineError{Line: q.line, Err: err})
+            continue
+        }
+        p.result = append(p.result, RateInfo{From: base, To: q.currency, Rate: rate, Provider: p.schema.Provider, EffectiveDate: effective})
+    }
+    return nil
+}
+
+// fail records an invalid entry
+func (p *jsonRateParser) fail(line int, field, message string) {
+    p.failures = append(p.failures, &LineError{
+        Line: line,
+        Err:  &ValidationError{Field: field, Message: message},
+    })
+}
+
+// stringValue reads the next value as text if it is a string or number, and
+// otherwise consumes it and reports false
+func (p *jsonRateParser) stringValue() (string, bool, error) {
+    token, err := p.decoder.Token()
+    if err != nil {
+        return "", false, err
+    }
+    switch value := token.(type) {
+    case string:
+        return value, true, nil
+    case json.Number:
+        return value.String(), true, nil
+    }
+    return "", false, p.skipRest(token)
+}
+
+// skipValue consumes the next value, including any nested objects or arrays
+func (p *jsonRateParser) skipValue() error {
+    token, err := p.decoder.Token()
+    if err != nil {
+        return err
+    }
+    return p.skipRest(token)
+}
+
+// skipRest consumes the remainder of a value whose first token has been read
+func (p *jsonRateParser) skipRest(token json.Token) error {
+    depth := 0
+    for {
+        switch token {
+        case json.Delim('{'), json.Delim('['):
+            depth++
+        case json.Delim('}'), json.Delim(']'):
+            depth--
+        }
+        if depth == 0 {
+            return nil
+        }
+        var err error
+        if token, err = p.decoder.Token(); err != nil {
+            return err
+        }
+    }
+}
+
+// parseRateEntry validates one rate read from a file
+func parseRateEntry(date, from, to, rate, layout, provider string) (RateInfo, error) {
+    effective, err := parseRateDate(date, layout)
+    if err != nil {
+        return RateInfo{}, err
+    }
+    if err := checkRateCurrency(from); err != nil {
+        return RateInfo{}, err
+    }
+    if err := checkRateCurrency(to); err != nil {
+        return RateInfo{}, err
+    }
+    exact, err := parseRateValue(rate, from, to)
+    if err != nil {
+        return RateInfo{}, err
+    }
+    return RateInfo{From: from, To: to, Rate: exact, Provider: provider, EffectiveDate: effective}, nil
+}
+
+// parseRateDate parses the effective date of a rate read from a file
+func parseRateDate(date, layout string) (time.Time, error) {
+    effective, err := time.Parse(layout, date)
+    if err != nil {
+        return time.Time{}, &ValidationError{
+            Field:   "date",
+            Message: fmt.Sprintf("invalid date %q", date),
+        }
+    }
+    return effective, nil
+}
+
+// checkRateCurrency validates a currency code read from a file
+func checkRateCurrency(code string) error {
+    if _, err := GetCurrency(code); err != nil {
+        return &ValidationError{
+            Field:   "currency",
+            Message: fmt.Sprintf("unknown currency %q", code),
+            Err:     err,
+        }
+    }
+    return nil
+}
+
+// parseRateValue parses a rate read from a file exactly, requiring it to be positive
+func parseRateValue(rate, from, to string) (Rate, error) {
+    exact, err := ParseRate(rate)
+    if err != nil {
+        return Rate{}, &ValidationError{
+            Field:   "rate",
+            Message: fmt.Sprintf("invalid rate %q for %s/%s", rate, from, to),
+        }
+    }
+    if !exact.IsPositive() {
+        return Rate{}, &ValidationError{
+            Field:   "rate",
+            Message: fmt.Sprintf("rate %s for %s/%s must be positive", rate, from, to),
+        }
+    }
+    return exact, nil
+}
+
+// LoadCSV reads a CSV rate file into the table. Nothing is loaded if any row is invalid.
+func (t *RateTable) LoadCSV(r io.Reader, schema CSVRateSchema) error {
+    rates, err := ReadCSVRates(r, schema)
+    if err != nil {
+        return err
+    }
+    return t.loadRates(rates, "")
+}
+
+// LoadJSON reads JSON rate snapshots into the table. Nothing is loaded if any entry is invalid.
+func (t *RateTable) LoadJSON(r io.Reader, schema JSONRateSchema) error {
+    rates, err := ReadJSONRates(r, schema)
+    if err != nil {
+        return err
+    }
+    return t.loadRates(rates, "")
+}
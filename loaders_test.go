@@ -0,0 +1,248 @@
+package money
+
+import (
+    "errors"
+    "strings"
+    "testing"
+)
+
+// lineFailure is an expected entry of a RateFileError
+type lineFailure struct {
+    line  int
+    field string
+}
+
+// checkRateFileError reports whether err is a *RateFileError listing exactly want
+func checkRateFileError(t *testing.T, err error, want []lineFailure) {
+    t.Helper()
+    var fileErr *RateFileError
+    if !errors.As(err, &fileErr) {
+        t.Fatalf("error = %v, want RateFileError", err)
+    }
+    if len(fileErr.Errors) != len(want) {
+        t.Fatalf("error = %v, want %d failures", err, len(want))
+    }
+    for i, w := range want {
+        var validation *ValidationError
+        got := fileErr.Errors[i]
+        if got.Line != w.line || !errors.As(got, &validation) || validation.Field != w.field {
+            t.Errorf("failure %d = %v, want %s at line %d", i, got, w.field, w.line)
+        }
+    }
+}
+
+func TestReadCSVRates(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        schema   CSVRateSchema
+        want     int
+        failures []lineFailure
+    }{
+        {
+            name:   "valid",
+            input:  "date,base,quote,rate\n2024-01-02,EUR,USD,1.0956\n2024-01-02,USD,JPY,142.5\n",
+            schema: DefaultCSVRateSchema,
+            want:   2,
+        },
+        {
+            name:   "columns in any order with another delimiter",
+            input:  "rate;quote;base;date\n1.0956;USD;EUR;2024-01-02\n",
+            schema: CSVRateSchema{DateColumn: "date", FromColumn: "base", ToColumn: "quote", RateColumn: "rate", DateLayout: "2006-01-02", Comma: ';'},
+            want:   1,
+        },
+        {
+            name:     "missing column",
+            input:    "date,base,rate\n2024-01-02,EUR,1.0956\n",
+            schema:   DefaultCSVRateSchema,
+            failures: []lineFailure{{1, "header"}},
+        },
+        {
+            name:   "every invalid row with its line",
+            input:  "date,base,quote,rate\n2024-01-02,EUR,USD,1.0956\n02/01/2024,EUR,USD,1.09\n2024-01-02,EUR,XYZ,1.2\n\n2024-01-02,EUR,GBP,-0.86\n2024-01-02,EUR,GBP,abc\n",
+            schema: DefaultCSVRateSchema,
+            failures: []lineFailure{
+                {3, "date"},
+                {4, "currency"},
+                {6, "rate"},
+                {7, "rate"},
+            },
+        },
+        {
+            name:     "quoted field spanning lines",
+            input:    "date,base,quote,rate\n\"2024-01-02\",EUR,USD,\"1.09\nx\"\n2024-01-02,EUR,XYZ,1.2\n",
+            schema:   DefaultCSVRateSchema,
+            failures: []lineFailure{{2, "rate"}, {4, "currency"}},
+        },
+        {
+            name:     "malformed CSV",
+            input:    "date,base,quote,rate\n2024-01-02,EUR,USD,1.09\n2024-01-02,EUR,\"GBP,0.86\n",
+            schema:   DefaultCSVRateSchema,
+            failures: []lineFailure{{3, "csv"}},
+        },
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            rates, err := ReadCSVRates(strings.NewReader(tt.input), tt.schema)
+            if tt.failures != nil {
+                if rates != nil {
+                    t.Errorf("ReadCSVRates() returned rates alongside errors: %v", rates)
+                }
+                checkRateFileError(t, err, tt.failures)
+                return
+            }
+            if err != nil {
+                t.Fatal(err)
+            }
+            if len(rates) != tt.want {
+                t.Fatalf("ReadCSVRates() returned %d rates, want %d", len(rates), tt.want)
+            }
+            if rates[0].From != "EUR" || rates[0].To != "USD" || rates[0].Rate.String() != "1.0956" ||
+                rates[0].Provider != tt.schema.Provider || !rates[0].EffectiveDate.Equal(day(2024, 1, 2)) {
+                t.Errorf("first rate = %+v", rates[0])
+            }
+        })
+    }
+}
+
+func TestReadJSONRates(t *testing.T) {
+    tests := []struct {
+        name     string
+        input    string
+        want     int
+        failures []lineFailure
+    }{
+        {
+            name:  "single snapshot with numbers and strings",
+            input: `{"date": "2024-01-02", "base": "EUR", "rates": {"USD": 1.0956, "JPY": "155.48"}}`,
+            want:  2,
+        },
+        {
+            name:  "array of snapshots with unknown keys",
+            input: "[\n{\"date\": \"2024-01-02\", \"base\": \"EUR\", \"source\": {\"name\": [1, 2]}, \"rates\": {\"USD\": 1.0956}},\n{\"date\": \"2024-01-03\", \"base\": \"EUR\", \"rates\": {\"USD\": 1.0919}}\n]",
+            want:  2,
+        },
+        {
+            name: "invalid rates with their lines",
+            input: `{
+    "date": "2024-01-02",
+    "base": "EUR",
+    "rates": {
+        "USD": 1.0956,
+        "XYZ": 1.2,
+        "GBP": -0.86,
+        "JPY": true
+    }
+}`,
+            failures: []lineFailure{{8, "rate"}, {6, "currency"}, {7, "rate"}},
+        },
+        {
+            name: "invalid date reported once",
+            input: `{
+    "date": "02/01/2024",
+    "base": "EUR",
+    "rates": {"USD": 1.0956, "GBP": 0.86}
+}`,
+            failures: []lineFailure{{2, "date"}},
+        },
+        {
+            name: "unknown base reported once",
+            input: `{
+    "date": "2024-01-02",
+    "base": "XYZ",
+    "rates": {"USD": 1.0956, "GBP": 0.86}
+}`,
+            failures: []lineFailure{{3, "currency"}},
+        },
+        {
+            name: "missing date and base",
+            input: `[{"date": "2024-01-02", "base": "EUR", "rates": {"USD": 1.0956}},
+{"rates": {"USD": 1.0956}}]`,
+            failures: []lineFailure{{2, "date"}, {2, "base"}},
+        },
+        {
+            name:     "non-string date",
+            input:    `{"date": 20240102, "base": "EUR", "rates": {"USD": 1.0956}}`,
+            failures: []lineFailure{{1, "date"}},
+        },
+        {
+            name:     "rates not an object",
+            input:    `{"date": "2024-01-02", "base": "EUR", "rates": [1.0956]}`,
+            failures: []lineFailure{{1, "rates"}},
+        },
+        {
+            name:     "no rates",
+            input:    `{"date": "2024-01-02", "base": "EUR", "rates": {}}`,
+            failures: []lineFailure{{1, "rates"}},
+        },
+        {
+            name:     "malformed JSON",
+            input:    "{\"date\": \"2024-01-02\",\n\"base\": \"EUR\",\n\"rates\": {\"USD\": 1.09,}}",
+            failures: []lineFailure{{3, "json"}},
+        },
+        {
+            name:     "not a snapshot",
+            input:    `"rates"`,
+            failures: []lineFailure{{1, "json"}},
+        },
+        {
+            name:     "trailing data",
+            input:    `{"date": "2024-01-02", "base": "EUR", "rates": {"USD": 1.0956}} {}`,
+            failures: []lineFailure{{1, "json"}},
+        },
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            rates, err := ReadJSONRates(strings.NewReader(tt.input), DefaultJSONRateSchema)
+            if tt.failures != nil {
+                if rates != nil {
+                    t.Errorf("ReadJSONRates() returned rates alongside errors: %v", rates)
+                }
+                checkRateFileError(t, err, tt.failures)
+                return
+            }
+            if err != nil {
+                t.Fatal(err)
+            }
+            if len(rates) != tt.want {
+                t.Fatalf("ReadJSONRates() returned %d rates, want %d", len(rates), tt.want)
+            }
+            if rates[0].From != "EUR" || rates[0].To != "USD" || rates[0].Rate.String() != "1.0956" ||
+                rates[0].Provider != "JSON" || !rates[0].EffectiveDate.Equal(day(2024, 1, 2)) {
+                t.Errorf("first rate = %+v", rates[0])
+            }
+        })
+    }
+}
+
+func TestRateFileErrorUnwrapsCurrency(t *testing.T) {
+    _, err := ReadCSVRates(strings.NewReader("date,base,quote,rate\n2024-01-02,EUR,XYZ,1.2\n"), DefaultCSVRateSchema)
+    var validation *ValidationError
+    if !errors.As(err, &validation) || validation.Field != "currency" || validation.Err == nil {
+        t.Errorf("error = %v, want a currency ValidationError wrapping the lookup error", err)
+    }
+}
+
+func TestRateTableLoadFiles(t *testing.T) {
+    table := NewRateTable()
+    if err := table.LoadCSV(strings.NewReader("date,base,quote,rate\n2024-01-02,EUR,USD,1.0956\n"), DefaultCSVRateSchema); err != nil {
+        t.Fatal(err)
+    }
+    if err := table.LoadJSON(strings.NewReader(`{"date": "2024-01-03", "base": "EUR", "rates": {"USD": "1.0919"}}`), DefaultJSONRateSchema); err != nil {
+        t.Fatal(err)
+    }
+    if err := table.LoadJSON(strings.NewReader(`{"date": "2024-01-04", "base": "EUR", "rates": {"USD": 1.1, "XYZ": 2}}`), DefaultJSONRateSchema); err == nil {
+        t.Fatal("LoadJSON() accepted an invalid file")
+    }
+
+    date := day(2024, 1, 2)
+    if rate, err := table.GetExactRate("EUR", "USD", &date); err != nil || rate.String() != "1.0956" {
+        t.Errorf("GetExactRate() on the CSV day = %v, %v", rate, err)
+    }
+    if rate, err := table.GetExactRate("EUR", "USD", nil); err != nil || rate.String() != "1.0919" {
+        t.Errorf("GetExactRate() = %v, %v, want the JSON rate and nothing from the invalid file", rate, err)
+    }
+    if table.Base != "" {
+        t.Errorf("Base = %q, want it left unset", table.Base)
+    }
+}
@@ -0,0 +1,208 @@
+package money
+
+import (
+    "encoding/csv"
+    "encoding/json"
+    "fmt"
+    "io"
+    "time"
+)
+
+// DefaultCrossRatePrecision is the number of decimal places cross rates are rounded
+// to when CrossRateOptions.Precision is zero
+const DefaultCrossRatePrecision = 6
+
+// CrossRateOptions configures CrossRateMatrix
+type CrossRateOptions struct {
+    // Base, when set, derives every cross rate from the rates against this currency,
+    // as rate(A,B) = rate(A,Base) * rate(Base,B), so only one lookup per currency is made.
+    // Otherwise the provider is asked for every pair.
+    Base string
+
+    Precision     int                  // Decimal places of rounded rates; DefaultCrossRatePrecision when zero
+    PairPrecision map[CurrencyPair]int // Overrides Precision for individual pairs, including zero
+    Rounding      RoundingMethod       // Method used to round rates for display and export
+}
+
+// RateMatrix is an N×N table of cross rates, where the rate in row i and column j
+// converts Codes[i] to Codes[j]. Exact rates are kept alongside the rounded ones.
+type RateMatrix struct {
+    Codes   []string
+    Date    *time.Time
+    Exact   [][]Rate
+    Rounded [][]Rate
+
+    places [][]int // Decimal places of each rounded rate, for export
+}
+
+// CrossRateMatrix builds the matrix of cross rates between codes using provider, or
+// DefaultRateProvider (or DefaultConverter) when provider is nil. A nil opts uses the defaults.
+func CrossRateMatrix(codes []string, provider RateProvider, date *time.Time, opts *CrossRateOptions) (*RateMatrix, error) {
+    if opts == nil {
+        opts = &CrossRateOptions{}
+    }
+    if provider == nil {
+        provider = defaultRateProvider()
+    }
+    if provider == nil {
+        return nil, &ValidationError{
+            Field:   "converter",
+            Message: "no rate provider or currency converter configured",
+        }
+    }
+    for _, code := range codes {
+        if _, err := GetCurrency(code); err != nil {
+            return nil, err
+        }
+    }
+
+    lookup := func(from, to string) (Rate, error) {
+        rate, err := provider.GetExactRate(from, to, date)
+        if err != nil {
+            return Rate{}, &ValidationError{
+                Field:   "exchange_rate",
+                Message: fmt.Sprintf("could not fetch rate from %s to %s: %v", from, to, err),
+            }
+        }
+        if !rate.IsPositive() {
+            return Rate{}, &ValidationError{
+                Field:   "exchange_rate",
+                Message: fmt.Sprintf("rate from %s to %s must be positive", from, to),
+            }
+        }
+        return rate, nil
+    }
+
+    // toBase[i] is the rate from codes[i] to opts.Base
+    var toBase []Rate
+    if opts.Base != "" {
+        toBase = make([]Rate, len(codes))
+        for i, code := range codes {
+            if code == opts.Base {
+                toBase[i] = MustParseRate("1")
+                continue
+            }
+            rate, err := lookup(code, opts.Base)
+            if err != nil {
+                return nil, err
+            }
+            toBase[i] = rate
+        }
+    }
+
+    matrix := &RateMatrix{
+        Codes:   append([]string(nil), codes...),
+        Date:    date,
+        Exact:   make([][]Rate, len(codes)),
+        Rounded: make([][]Rate, len(codes)),
+        places:  make([][]int, len(codes)),
+    }
+    for i, from := range codes {
+        matrix.Exact[i] = make([]Rate, len(codes))
+        matrix.Rounded[i] = make([]Rate, len(codes))
+        matrix.places[i] = make([]int, len(codes))
+        for j, to := range codes {
+            var rate Rate
+            switch {
+            case from == to:
+                rate = MustParseRate("1")
+            case toBase != nil:
+                rate = toBase[i].Mul(toBase[j].Inverse())
+            default:
+                var err error
+                if rate, err = lookup(from, to); err != nil {
+                    return nil, err
+                }
+            }
+            matrix.Exact[i][j] = rate
+            matrix.places[i][j] = opts.precision(from, to)
+            matrix.Rounded[i][j] = rate.Round(matrix.places[i][j], opts.Rounding)
+        }
+    }
+    return matrix, nil
+}
+
+// precision returns the decimal places used for a pair
+func (o *CrossRateOptions) precision(from, to string) int {
+    if places, ok := o.PairPrecision[CurrencyPair{From: from, To: to}]; ok {
+        return places
+    }
+    if o.Precision != 0 {
+        return o.Precision
+    }
+    return DefaultCrossRatePrecision
+}
+
+// Rate returns the rounded rate from one currency to another in the matrix
+func (m *RateMatrix) Rate(from, to string) (Rate, bool) {
+    i, j := m.index(from), m.index(to)
+    if i < 0 || j < 0 {
+        return Rate{}, false
+    }
+    return m.Rounded[i][j], true
+}
+
+// ExactRate returns the unrounded rate from one currency to another in the matrix
+func (m *RateMatrix) ExactRate(from, to string) (Rate, bool) {
+    i, j := m.index(from), m.index(to)
+    if i < 0 || j < 0 {
+        return Rate{}, false
+    }
+    return m.Exact[i][j], true
+}
+
+// format returns a rounded rate with its pair's number of decimal places
+func (m *RateMatrix) format(i, j int) string {
+    if m.places == nil {
+        return m.Rounded[i][j].String()
+    }
+    return m.Rounded[i][j].rat().FloatString(m.places[i][j])
+}
+
+// index returns the position of a currency in Codes, or -1
+func (m *RateMatrix) index(code string) int {
+    for i, c := range m.Codes {
+        if c == code {
+            return i
+        }
+    }
+    return -1
+}
+
+// WriteCSV writes the rounded rates as CSV with a header row of target currencies
+// and a leading column of source currencies
+func (m *RateMatrix) WriteCSV(w io.Writer) error {
+    writer := csv.NewWriter(w)
+    if err := writer.Write(append([]string{""}, m.Codes...)); err != nil {
+        return err
+    }
+    for i, from := range m.Codes {
+        row := make([]string, 0, len(m.Codes)+1)
+        row = append(row, from)
+        for j := range m.Codes {
+            row = append(row, m.format(i, j))
+        }
+        if err := writer.Write(row); err != nil {
+            return err
+        }
+    }
+    writer.Flush()
+    return writer.Error()
+}
+
+// MarshalJSON encodes the rounded rates as
+// {"date": ..., "codes": [...], "rates": {"USD": {"EUR": "0.912300", ...}, ...}}
+func (m *RateMatrix) MarshalJSON() ([]byte, error) {
+    rates := make(map[string]map[string]string, len(m.Codes))
+    for i, from := range m.Codes {
+        rates[from] = make(map[string]string, len(m.Codes))
+        for j, to := range m.Codes {
+            rates[from][to] = m.format(i, j)
+        }
+    }
+    return json.Marshal(struct {
+        Date  *time.Time                   `json:"date,omitempty"`
+        Codes []string                     `json:"codes"`
+        Rates map[string]map[string]string `json:"rates"`
+    }{m.Date, m.Codes, rates})
+}
@@ -0,0 +1,157 @@
+package money
+
+import (
+    "encoding/json"
+    "errors"
+    "strings"
+    "testing"
+)
+
+// matrixTable returns a RateTable with rates against USD and no Base
+func matrixTable(t *testing.T) *RateTable {
+    t.Helper()
+    table := NewRateTable()
+    for _, r := range []struct {
+        from, to, rate string
+    }{
+        {"EUR", "USD", "1.1"},
+        {"USD", "JPY", "150"},
+        {"GBP", "USD", "1.25"},
+    } {
+        if err := table.SetRate(r.from, r.to, MustParseRate(r.rate), day(2024, 1, 2)); err != nil {
+            t.Fatal(err)
+        }
+    }
+    return table
+}
+
+func TestCrossRateMatrix(t *testing.T) {
+    codes := []string{"EUR", "USD", "JPY", "GBP"}
+
+    tests := []struct {
+        name      string
+        opts      *CrossRateOptions
+        from, to  string
+        wantExact Rate
+        want      string // Rounded rate
+    }{
+        {"diagonal", nil, "JPY", "JPY", MustParseRate("1"), "1"},
+        {"through the base", &CrossRateOptions{Base: "USD"}, "EUR", "JPY", MustParseRate("165"), "165"},
+        {"inverse through the base", &CrossRateOptions{Base: "USD"}, "JPY", "EUR", MustParseRate("1/165"), "0.006061"},
+        {"default precision", &CrossRateOptions{Base: "USD"}, "GBP", "EUR", MustParseRate("25/22"), "1.136364"},
+        {"precision", &CrossRateOptions{Base: "USD", Precision: 2}, "GBP", "EUR", MustParseRate("25/22"), "1.14"},
+        {"rounding method", &CrossRateOptions{Base: "USD", Rounding: RoundDown}, "GBP", "EUR", MustParseRate("25/22"), "1.136363"},
+        {
+            name: "pair precision overrides precision",
+            opts: &CrossRateOptions{Base: "USD", Precision: 2, PairPrecision: map[CurrencyPair]int{{From: "GBP", To: "EUR"}: 4}},
+            from: "GBP", to: "EUR", wantExact: MustParseRate("25/22"), want: "1.1364",
+        },
+        {
+            name: "pair precision of zero",
+            opts: &CrossRateOptions{Base: "USD", PairPrecision: map[CurrencyPair]int{{From: "JPY", To: "GBP"}: 0}},
+            from: "JPY", to: "GBP", wantExact: MustParseRate("2/375"), want: "0",
+        },
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            table := matrixTable(t)
+            table.Base = "USD"
+            matrix, err := CrossRateMatrix(codes, table, nil, tt.opts)
+            if err != nil {
+                t.Fatal(err)
+            }
+            exact, ok := matrix.ExactRate(tt.from, tt.to)
+            if !ok || exact.Cmp(tt.wantExact) != 0 {
+                t.Errorf("ExactRate() = %v, want %v", exact, tt.wantExact)
+            }
+            rounded, ok := matrix.Rate(tt.from, tt.to)
+            if !ok || rounded.Cmp(MustParseRate(tt.want)) != 0 {
+                t.Errorf("Rate() = %v, want %s", rounded, tt.want)
+            }
+        })
+    }
+}
+
+func TestCrossRateMatrixLookups(t *testing.T) {
+    table := matrixTable(t)
+    codes := []string{"EUR", "USD", "JPY"}
+
+    // Without a Base in the options every pair is looked up, and the table cannot cross EUR/JPY
+    var validation *ValidationError
+    if _, err := CrossRateMatrix(codes, table, nil, nil); !errors.As(err, &validation) || validation.Field != "exchange_rate" {
+        t.Errorf("CrossRateMatrix() error = %v, want exchange_rate ValidationError", err)
+    }
+    if _, err := CrossRateMatrix(codes, table, nil, &CrossRateOptions{Base: "USD"}); err != nil {
+        t.Errorf("CrossRateMatrix() through USD error = %v", err)
+    }
+    if _, err := CrossRateMatrix(codes, table, nil, &CrossRateOptions{Base: "GBP"}); err == nil {
+        t.Error("CrossRateMatrix() succeeded through a base missing from the table")
+    }
+    if _, err := CrossRateMatrix([]string{"EUR", "XYZ"}, table, nil, nil); err == nil {
+        t.Error("CrossRateMatrix() accepted an unknown currency")
+    }
+
+    matrix, err := CrossRateMatrix([]string{"EUR", "USD"}, table, nil, nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if _, ok := matrix.Rate("EUR", "JPY"); ok {
+        t.Error("Rate() found a currency outside the matrix")
+    }
+    if _, ok := matrix.ExactRate("GBP", "USD"); ok {
+        t.Error("ExactRate() found a currency outside the matrix")
+    }
+}
+
+func TestCrossRateMatrixDefaults(t *testing.T) {
+    savedProvider, savedConverter := DefaultRateProvider, DefaultConverter
+    defer func() { DefaultRateProvider, DefaultConverter = savedProvider, savedConverter }()
+
+    DefaultRateProvider, DefaultConverter = nil, mapConverter{{From: "EUR", To: "USD"}: 1.1, {From: "USD", To: "EUR"}: 0.9}
+    matrix, err := CrossRateMatrix([]string{"EUR", "USD"}, nil, nil, nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if rate, _ := matrix.Rate("USD", "EUR"); rate.Cmp(MustParseRate("0.9")) != 0 {
+        t.Errorf("Rate() from DefaultConverter = %v, want 0.9", rate)
+    }
+
+    DefaultConverter = nil
+    var validation *ValidationError
+    if _, err := CrossRateMatrix([]string{"EUR", "USD"}, nil, nil, nil); !errors.As(err, &validation) || validation.Field != "converter" {
+        t.Errorf("CrossRateMatrix() without a provider error = %v, want converter ValidationError", err)
+    }
+}
+
+func TestRateMatrixExport(t *testing.T) {
+    date := day(2024, 1, 2)
+    opts := &CrossRateOptions{Base: "USD", Precision: 4, PairPrecision: map[CurrencyPair]int{{From: "USD", To: "JPY"}: 0}}
+    matrix, err := CrossRateMatrix([]string{"USD", "JPY"}, matrixTable(t), &date, opts)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    var csv strings.Builder
+    if err := matrix.WriteCSV(&csv); err != nil {
+        t.Fatal(err)
+    }
+    wantCSV := ",USD,JPY\nUSD,1.0000,150\nJPY,0.0067,1.0000\n"
+    if csv.String() != wantCSV {
+        t.Errorf("WriteCSV() = %q, want %q", csv.String(), wantCSV)
+    }
+
+    data, err := json.Marshal(matrix)
+    if err != nil {
+        t.Fatal(err)
+    }
+    wantJSON := `{"date":"2024-01-02T00:00:00Z","codes":["USD","JPY"],"rates":{"JPY":{"JPY":"1.0000","USD":"0.0067"},"USD":{"JPY":"150","USD":"1.0000"}}}`
+    if string(data) != wantJSON {
+        t.Errorf("MarshalJSON() = %s, want %s", data, wantJSON)
+    }
+
+    // A matrix built by hand has no recorded precision and exports each rate's shortest form
+    manual := &RateMatrix{Codes: []string{"EUR"}, Exact: [][]Rate{{MustParseRate("1")}}, Rounded: [][]Rate{{MustParseRate("1")}}}
+    if data, err := json.Marshal(manual); err != nil || string(data) != `{"codes":["EUR"],"rates":{"EUR":{"EUR":"1"}}}` {
+        t.Errorf("MarshalJSON() of a manual matrix = %s, %v", data, err)
+    }
+}
@@ -0,0 +1,381 @@
+package money
+
+import (
+    "encoding/json"
+    "fmt"
+    "io"
+    "math"
+    "strings"
+    "sync"
+)
+
+// PluralCategory identifies a CLDR plural form used to select a display name
+type PluralCategory int
+
+const (
+    PluralOther PluralCategory = iota
+    PluralZero
+    PluralOne
+    PluralTwo
+    PluralFew
+    PluralMany
+)
+
+var pluralCategoryNames = map[PluralCategory]string{
+    PluralOther: "other",
+    PluralZero:  "zero",
+    PluralOne:   "one",
+    PluralTwo:   "two",
+    PluralFew:   "few",
+    PluralMany:  "many",
+}
+
+// String returns the CLDR keyword for the category ("one", "other", ...)
+func (p PluralCategory) String() string {
+    if name, ok := pluralCategoryNames[p]; ok {
+        return name
+    }
+    return fmt.Sprintf("PluralCategory(%d)", int(p))
+}
+
+// MarshalText implements encoding.TextMarshaler using the CLDR keyword
+func (p PluralCategory) MarshalText() ([]byte, error) {
+    return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for CLDR keywords
+func (p *PluralCategory) UnmarshalText(text []byte) error {
+    for category, name := range pluralCategoryNames {
+        if name == string(text) {
+            *p = category
+            return nil
+        }
+    }
+    return &ValidationError{
+        Field:   "plural category",
+        Message: fmt.Sprintf("unknown plural category %q", string(text)),
+    }
+}
+
+// PluralRule selects the plural category for a count.
+// The integer part of the count is passed in integer, and fractional reports
+// whether the displayed number has a non-zero fraction (e.g. 1.50).
+type PluralRule func(integer int64, fractional bool) PluralCategory
+
+// pluralOneIfExactlyOne is the rule used by English, German, Spanish and most
+// languages without a registered rule
+func pluralOneIfExactlyOne(integer int64, fractional bool) PluralCategory {
+    if integer == 1 && !fractional {
+        return PluralOne
+    }
+    return PluralOther
+}
+
+// pluralOneIfZeroOrOne is the rule used by French and Portuguese
+func pluralOneIfZeroOrOne(integer int64, fractional bool) PluralCategory {
+    if integer == 0 || integer == 1 {
+        return PluralOne
+    }
+    return PluralOther
+}
+
+// CurrencyNameSet maps plural categories to the display name for that form
+type CurrencyNameSet map[PluralCategory]string
+
+var (
+    namesMu sync.RWMutex
+
+    pluralRules = map[string]PluralRule{
+        "en": pluralOneIfExactlyOne,
+        "de": pluralOneIfExactlyOne,
+        "es": pluralOneIfExactlyOne,
+        "fr": pluralOneIfZeroOrOne,
+        "pt": pluralOneIfZeroOrOne,
+    }
+
+    // currencyNames holds display names keyed by language and currency code
+    currencyNames = map[string]map[string]CurrencyNameSet{
+        "en": {
+            "USD": {PluralOne: "US dollar", PluralOther: "US dollars"},
+            "EUR": {PluralOne: "euro", PluralOther: "euros"},
+            "JPY": {PluralOne: "Japanese yen", PluralOther: "Japanese yen"},
+            "GBP": {PluralOne: "British pound", PluralOther: "British pounds"},
+            "CHF": {PluralOne: "Swiss franc", PluralOther: "Swiss francs"},
+            "BRL": {PluralOne: "Brazilian real", PluralOther: "Brazilian reais"},
+            "ARS": {PluralOne: "Argentine peso", PluralOther: "Argentine pesos"},
+            "UYU": {PluralOne: "Uruguayan peso", PluralOther: "Uruguayan pesos"},
+            "CLP": {PluralOne: "Chilean peso", PluralOther: "Chilean pesos"},
+            "CAD": {PluralOne: "Canadian dollar", PluralOther: "Canadian dollars"},
+            "MXN": {PluralOne: "Mexican peso", PluralOther: "Mexican pesos"},
+            "CNY": {PluralOne: "Chinese yuan", PluralOther: "Chinese yuan"},
+            "HKD": {PluralOne: "Hong Kong dollar", PluralOther: "Hong Kong dollars"},
+            "SGD": {PluralOne: "Singapore dollar", PluralOther: "Singapore dollars"},
+            "INR": {PluralOne: "Indian rupee", PluralOther: "Indian rupees"},
+            "KRW": {PluralOne: "South Korean won", PluralOther: "South Korean won"},
+            "TWD": {PluralOne: "New Taiwan dollar", PluralOther: "New Taiwan dollars"},
+            "AUD": {PluralOne: "Australian dollar", PluralOther: "Australian dollars"},
+            "NZD": {PluralOne: "New Zealand dollar", PluralOther: "New Zealand dollars"},
+            "SEK": {PluralOne: "Swedish krona", PluralOther: "Swedish kronor"},
+            "NOK": {PluralOne: "Norwegian krone", PluralOther: "Norwegian kroner"},
+            "DKK": {PluralOne: "Danish krone", PluralOther: "Danish kroner"},
+            "HUF": {PluralOne: "Hungarian forint", PluralOther: "Hungarian forints"},
+            "ISK": {PluralOne: "Icelandic króna", PluralOther: "Icelandic krónur"},
+            "BHD": {PluralOne: "Bahraini dinar", PluralOther: "Bahraini dinars"},
+            "JOD": {PluralOne: "Jordanian dinar", PluralOther: "Jordanian dinars"},
+            "KWD": {PluralOne: "Kuwaiti dinar", PluralOther: "Kuwaiti dinars"},
+            "OMR": {PluralOne: "Omani rial", PluralOther: "Omani rials"},
+            "TND": {PluralOne: "Tunisian dinar", PluralOther: "Tunisian dinars"},
+        },
+        "es": {
+            "USD": {PluralOne: "dólar estadounidense", PluralOther: "dólares estadounidenses"},
+            "EUR": {PluralOne: "euro", PluralOther: "euros"},
+            "JPY": {PluralOne: "yen japonés", PluralOther: "yenes japoneses"},
+            "GBP": {PluralOne: "libra esterlina", PluralOther: "libras esterlinas"},
+            "CHF": {PluralOne: "franco suizo", PluralOther: "francos suizos"},
+            "BRL": {PluralOne: "real brasileño", PluralOther: "reales brasileños"},
+            "ARS": {PluralOne: "peso argentino", PluralOther: "pesos argentinos"},
+            "UYU": {PluralOne: "peso uruguayo", PluralOther: "pesos uruguayos"},
+            "CLP": {PluralOne: "peso chileno", PluralOther: "pesos chilenos"},
+            "CAD": {PluralOne: "dólar canadiense", PluralOther: "dólares canadienses"},
+            "MXN": {PluralOne: "peso mexicano", PluralOther: "pesos mexicanos"},
+            "CNY": {PluralOne: "yuan chino", PluralOther: "yuanes chinos"},
+            "HKD": {PluralOne: "dólar hongkonés", PluralOther: "dólares hongkoneses"},
+            "SGD": {PluralOne: "dólar singapurense", PluralOther: "dólares singapurenses"},
+            "INR": {PluralOne: "rupia india", PluralOther: "rupias indias"},
+            "KRW": {PluralOne: "won surcoreano", PluralOther: "wones surcoreanos"},
+            "TWD": {PluralOne: "nuevo dólar taiwanés", PluralOther: "nuevos dólares taiwaneses"},
+            "AUD": {PluralOne: "dólar australiano", PluralOther: "dólares australianos"},
+            "NZD": {PluralOne: "dólar neozelandés", PluralOther: "dólares neozelandeses"},
+            "SEK": {PluralOne: "corona sueca", PluralOther: "coronas suecas"},
+            "NOK": {PluralOne: "corona noruega", PluralOther: "coronas noruegas"},
+            "DKK": {PluralOne: "corona danesa", PluralOther: "coronas danesas"},
+            "HUF": {PluralOne: "forinto húngaro", PluralOther: "forintos húngaros"},
+            "ISK": {PluralOne: "corona islandesa", PluralOther: "coronas islandesas"},
+            "BHD": {PluralOne: "dinar bareiní", PluralOther: "dinares bareiníes"},
+            "JOD": {PluralOne: "dinar jordano", PluralOther: "dinares jordanos"},
+            "KWD": {PluralOne: "dinar kuwaití", PluralOther: "dinares kuwaitíes"},
+            "OMR": {PluralOne: "rial omaní", PluralOther: "riales omaníes"},
+            "TND": {PluralOne: "dinar tunecino", PluralOther: "dinares tunecinos"},
+        },
+        "pt": {
+            "USD": {PluralOne: "dólar americano", PluralOther: "dólares americanos"},
+            "EUR": {PluralOne: "euro", PluralOther: "euros"},
+            "JPY": {PluralOne: "iene japonês", PluralOther: "ienes japoneses"},
+            "GBP": {PluralOne: "libra esterlina", PluralOther: "libras esterlinas"},
+            "CHF": {PluralOne: "franco suíço", PluralOther: "francos suíços"},
+            "BRL": {PluralOne: "real brasileiro", PluralOther: "reais brasileiros"},
+            "ARS": {PluralOne: "peso argentino", PluralOther: "pesos argentinos"},
+            "UYU": {PluralOne: "peso uruguaio", PluralOther: "pesos uruguaios"},
+            "CLP": {PluralOne: "peso chileno", PluralOther: "pesos chilenos"},
+            "CAD": {PluralOne: "dólar canadense", PluralOther: "dólares canadenses"},
+            "MXN": {PluralOne: "peso mexicano", PluralOther: "pesos mexicanos"},
+            "CNY": {PluralOne: "yuan chinês", PluralOther: "yuans chineses"},
+            "HKD": {PluralOne: "dólar de Hong Kong", PluralOther: "dólares de Hong Kong"},
+            "SGD": {PluralOne: "dólar de Singapura", PluralOther: "dólares de Singapura"},
+            "INR": {PluralOne: "rupia indiana", PluralOther: "rupias indianas"},
+            "KRW": {PluralOne: "won sul-coreano", PluralOther: "wons sul-coreanos"},
+            "TWD": {PluralOne: "novo dólar taiwanês", PluralOther: "novos dólares taiwaneses"},
+            "AUD": {PluralOne: "dólar australiano", PluralOther: "dólares australianos"},
+            "NZD": {PluralOne: "dólar neozelandês", PluralOther: "dólares neozelandeses"},
+            "SEK": {PluralOne: "coroa sueca", PluralOther: "coroas suecas"},
+            "NOK": {PluralOne: "coroa norueguesa", PluralOther: "coroas norueguesas"},
+            "DKK": {PluralOne: "coroa dinamarquesa", PluralOther: "coroas dinamarquesas"},
+            "HUF": {PluralOne: "florim húngaro", PluralOther: "florins húngaros"},
+            "ISK": {PluralOne: "coroa islandesa", PluralOther: "coroas islandesas"},
+            "BHD": {PluralOne: "dinar bareinita", PluralOther: "dinares bareinitas"},
+            "JOD": {PluralOne: "dinar jordaniano", PluralOther: "dinares jordanianos"},
+            "KWD": {PluralOne: "dinar kuwaitiano", PluralOther: "dinares kuwaitianos"},
+            "OMR": {PluralOne: "rial omanense", PluralOther: "riais omanenses"},
+            "TND": {PluralOne: "dinar tunisiano", PluralOther: "dinares tunisianos"},
+        },
+        "fr": {
+            "USD": {PluralOne: "dollar américain", PluralOther: "dollars américains"},
+            "EUR": {PluralOne: "euro", PluralOther: "euros"},
+            "JPY": {PluralOne: "yen japonais", PluralOther: "yens japonais"},
+            "GBP": {PluralOne: "livre sterling", PluralOther: "livres sterling"},
+            "CHF": {PluralOne: "franc suisse", PluralOther: "francs suisses"},
+            "BRL": {PluralOne: "réal brésilien", PluralOther: "réals brésiliens"},
+            "ARS": {PluralOne: "peso argentin", PluralOther: "pesos argentins"},
+            "UYU": {PluralOne: "peso uruguayen", PluralOther: "pesos uruguayens"},
+            "CLP": {PluralOne: "peso chilien", PluralOther: "pesos chiliens"},
+            "CAD": {PluralOne: "dollar canadien", PluralOther: "dollars canadiens"},
+            "MXN": {PluralOne: "peso mexicain", PluralOther: "pesos mexicains"},
+            "CNY": {PluralOne: "yuan chinois", PluralOther: "yuans chinois"},
+            "HKD": {PluralOne: "dollar de Hong Kong", PluralOther: "dollars de Hong Kong"},
+            "SGD": {PluralOne: "dollar de Singapour", PluralOther: "dollars de Singapour"},
+            "INR": {PluralOne: "roupie indienne", PluralOther: "roupies indiennes"},
+            "KRW": {PluralOne: "won sud-coréen", PluralOther: "wons sud-coréens"},
+            "TWD": {PluralOne: "nouveau dollar taïwanais", PluralOther: "nouveaux dollars taïwanais"},
+            "AUD": {PluralOne: "dollar australien", PluralOther: "dollars australiens"},
+            "NZD": {PluralOne: "dollar néo-zélandais", PluralOther: "dollars néo-zélandais"},
+            "SEK": {PluralOne: "couronne suédoise", PluralOther: "couronnes suédoises"},
+            "NOK": {PluralOne: "couronne norvégienne", PluralOther: "couronnes norvégiennes"},
+            "DKK": {PluralOne: "couronne danoise", PluralOther: "couronnes danoises"},
+            "HUF": {PluralOne: "forint hongrois", PluralOther: "forints hongrois"},
+            "ISK": {PluralOne: "couronne islandaise", PluralOther: "couronnes islandaises"},
+            "BHD": {PluralOne: "dinar bahreïni", PluralOther: "dinars bahreïnis"},
+            "JOD": {PluralOne: "dinar jordanien", PluralOther: "dinars jordaniens"},
+            "KWD": {PluralOne: "dinar koweïtien", PluralOther: "dinars koweïtiens"},
+            "OMR": {PluralOne: "rial omanais", PluralOther: "rials omanais"},
+            "TND": {PluralOne: "dinar tunisien", PluralOther: "dinars tunisiens"},
+        },
+        "de": {
+            "USD": {PluralOne: "US-Dollar", PluralOther: "US-Dollar"},
+            "EUR": {PluralOne: "Euro", PluralOther: "Euro"},
+            "JPY": {PluralOne: "Japanischer Yen", PluralOther: "Japanische Yen"},
+            "GBP": {PluralOne: "Britisches Pfund", PluralOther: "Britische Pfund"},
+            "CHF": {PluralOne: "Schweizer Franken", PluralOther: "Schweizer Franken"},
+            "BRL": {PluralOne: "Brasilianischer Real", PluralOther: "Brasilianische Real"},
+            "ARS": {PluralOne: "Argentinischer Peso", PluralOther: "Argentinische Pesos"},
+            "UYU": {PluralOne: "Uruguayischer Peso", PluralOther: "Uruguayische Pesos"},
+            "CLP": {PluralOne: "Chilenischer Peso", PluralOther: "Chilenische Pesos"},
+            "CAD": {PluralOne: "Kanadischer Dollar", PluralOther: "Kanadische Dollar"},
+            "MXN": {PluralOne: "Mexikanischer Peso", PluralOther: "Mexikanische Pesos"},
+            "CNY": {PluralOne: "Renminbi Yuan", PluralOther: "Renminbi Yuan"},
+            "HKD": {PluralOne: "Hongkong-Dollar", PluralOther: "Hongkong-Dollar"},
+            "SGD": {PluralOne: "Singapur-Dollar", PluralOther: "Singapur-Dollar"},
+            "INR": {PluralOne: "Indische Rupie", PluralOther: "Indische Rupien"},
+            "KRW": {PluralOne: "Südkoreanischer Won", PluralOther: "Südkoreanische Won"},
+            "TWD": {PluralOne: "Neuer Taiwan-Dollar", PluralOther: "Neue Taiwan-Dollar"},
+            "AUD": {PluralOne: "Australischer Dollar", PluralOther: "Australische Dollar"},
+            "NZD": {PluralOne: "Neuseeland-Dollar", PluralOther: "Neuseeland-Dollar"},
+            "SEK": {PluralOne: "Schwedische Krone", PluralOther: "Schwedische Kronen"},
+            "NOK": {PluralOne: "Norwegische Krone", PluralOther: "Norwegische Kronen"},
+            "DKK": {PluralOne: "Dänische Krone", PluralOther: "Dänische Kronen"},
+            "HUF": {PluralOne: "Ungarischer Forint", PluralOther: "Ungarische Forint"},
+            "ISK": {PluralOne: "Isländische Krone", PluralOther: "Isländische Kronen"},
+            "BHD": {PluralOne: "Bahrain-Dinar", PluralOther: "Bahrain-Dinar"},
+            "JOD": {PluralOne: "Jordanischer Dinar", PluralOther: "Jordanische Dinar"},
+            "KWD": {PluralOne: "Kuwait-Dinar", PluralOther: "Kuwait-Dinar"},
+            "OMR": {PluralOne: "Omanischer Rial", PluralOther: "Omanische Rial"},
+            "TND": {PluralOne: "Tunesischer Dinar", PluralOther: "Tunesische Dinar"},
+        },
+    }
+)
+
+// RegisterPluralRule sets the plural rule used for a language
+func RegisterPluralRule(lang string, rule PluralRule) {
+    namesMu.Lock()
+    defer namesMu.Unlock()
+    pluralRules[normalizeLanguage(lang)] = rule
+}
+
+// RegisterCurrencyNames adds or replaces the display names of a currency in a language.
+// Categories not present in names keep their previously registered value.
+func RegisterCurrencyNames(lang, code string, names CurrencyNameSet) error {
+    if _, err := GetCurrency(code); err != nil {
+        return err
+    }
+
+    lang = normalizeLanguage(lang)
+    namesMu.Lock()
+    defer namesMu.Unlock()
+
+    byCode, exists := currencyNames[lang]
+    if !exists {
+        byCode = make(map[string]CurrencyNameSet)
+        currencyNames[lang] = byCode
+    }
+    set, exists := byCode[code]
+    if !exists {
+        set = make(CurrencyNameSet)
+        byCode[code] = set
+    }
+    for category, name := range names {
+        set[category] = name
+    }
+    return nil
+}
+
+// LoadCurrencyNames reads display names from JSON and registers them.
+// The expected shape is {"lang": {"CODE": {"one": "...", "other": "..."}}}.
+func LoadCurrencyNames(r io.Reader) error {
+    var table map[string]map[string]CurrencyNameSet
+    if err := json.NewDecoder(r).Decode(&table); err != nil {
+        return fmt.Errorf("load currency names: %w", err)
+    }
+
+    for lang, byCode := range table {
+        for code, names := range byCode {
+            if err := RegisterCurrencyNames(lang, code, names); err != nil {
+                return fmt.Errorf("load currency names for %s: %w", lang, err)
+            }
+        }
+    }
+    return nil
+}
+
+// CurrencyName returns the display name of a currency in a language for a whole count.
+// Regional tags such as "pt-BR" fall back to their base language, languages
+// without registered names fall back to the registered "en" names, and currencies
+// without any registered names fall back to the English names in CurrencyMap.
+func CurrencyName(code, lang string, count int64) string {
+    return currencyName(code, lang, count, false)
+}
+
+// CurrencyName returns the display name of the Money's currency in a language,
+// using the plural form that matches the formatted amount
+func (m *Money) CurrencyName(lang string) string {
+    factor := int64(math.Pow10(m.currency.details().Precision))
+    integer := m.amount / factor
+    if integer < 0 {
+        integer = -integer
+    }
+    return currencyName(m.currency.code(), lang, integer, m.amount%factor != 0)
+}
+
+func currencyName(code, lang string, integer int64, fractional bool) string {
+    namesMu.RLock()
+    defer namesMu.RUnlock()
+
+    candidates := languageFallbacks(lang)
+    if len(candidates) == 0 || candidates[len(candidates)-1] != "en" {
+        candidates = append(candidates, "en")
+    }
+    for _, candidate := range candidates {
+        set, exists := currencyNames[candidate][code]
+        if !exists {
+            continue
+        }
+        rule, exists := pluralRules[candidate]
+        if !exists {
+            rule = pluralOneIfExactlyOne
+        }
+        if name, ok := set[rule(integer, fractional)]; ok {
+            return name
+        }
+        if name, ok := set[PluralOther]; ok {
+            return name
+        }
+    }
+
+    currency, exists := CurrencyMap[code]
+    if !exists {
+        return code
+    }
+    if pluralOneIfExactlyOne(integer, fractional) == PluralOne {
+        return currency.SingularName
+    }
+    return currency.PluralName
+}
+
+// normalizeLanguage lowercases a language tag and uses "-" as the subtag separator
+func normalizeLanguage(lang string) string {
+    return strings.ToLower(strings.ReplaceAll(lang, "_", "-"))
+}
+
+// languageFallbacks returns a tag followed by its progressively shorter prefixes,
+// e.g. "pt-br" then "pt"
+func languageFallbacks(lang string) []string {
+    lang = normalizeLanguage(lang)
+    var result []string
+    for lang != "" {
+        result = append(result, lang)
+        i := strings.LastIndex(lang, "-")
+        if i < 0 {
+            break
+        }
+        lang = lang[:i]
+    }
+    return result
+}
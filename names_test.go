@@ -0,0 +1,131 @@
+package money
+
+import (
+    "strings"
+    "testing"
+)
+
+func TestCurrencyName(t *testing.T) {
+    tests := []struct {
+        name  string
+        code  string
+        lang  string
+        count int64
+        want  string
+    }{
+        {"english singular", "USD", "en", 1, "US dollar"},
+        {"english plural", "USD", "en", 2, "US dollars"},
+        {"english zero is plural", "USD", "en", 0, "US dollars"},
+        {"spanish plural", "USD", "es", 5, "dólares estadounidenses"},
+        {"french zero is singular", "EUR", "fr", 0, "euro"},
+        {"portuguese one", "BRL", "pt", 1, "real brasileiro"},
+        {"regional tag falls back", "BRL", "pt-BR", 2, "reais brasileiros"},
+        {"underscore tag falls back", "BRL", "pt_BR", 2, "reais brasileiros"},
+        {"tag is case-insensitive", "JPY", "FR", 3, "yens japonais"},
+        {"unknown language uses english", "JPY", "sw", 2, "Japanese yen"},
+        {"empty language uses english", "USD", "", 1, "US dollar"},
+        {"unknown currency returns code", "XXX", "en", 1, "XXX"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := CurrencyName(tt.code, tt.lang, tt.count); got != tt.want {
+                t.Errorf("CurrencyName(%q, %q, %d) = %q, want %q", tt.code, tt.lang, tt.count, got, tt.want)
+            }
+        })
+    }
+}
+
+func TestMoneyCurrencyName(t *testing.T) {
+    tests := []struct {
+        amount int64
+        code   string
+        lang   string
+        want   string
+    }{
+        {100, "USD", "en", "US dollar"},
+        {150, "USD", "en", "US dollars"},
+        {-100, "USD", "en", "US dollar"},
+        {150, "EUR", "fr", "euro"},
+        {250, "EUR", "fr", "euros"},
+        {1, "JPY", "es", "yen japonés"},
+        {1000, "OMR", "de", "Omanischer Rial"},
+    }
+    for _, tt := range tests {
+        m, err := New(tt.amount, tt.code)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if got := m.CurrencyName(tt.lang); got != tt.want {
+            t.Errorf("CurrencyName(%q) for %d %s = %q, want %q", tt.lang, tt.amount, tt.code, got, tt.want)
+        }
+    }
+}
+
+func TestRegisterCurrencyNames(t *testing.T) {
+    if err := RegisterCurrencyNames("x-test", "XXX", CurrencyNameSet{PluralOther: "x"}); err == nil {
+        t.Error("RegisterCurrencyNames accepted an unknown currency")
+    }
+
+    RegisterPluralRule("x-test", func(integer int64, fractional bool) PluralCategory {
+        if integer == 2 {
+            return PluralTwo
+        }
+        return PluralOther
+    })
+    if err := RegisterCurrencyNames("x-test", "CHF", CurrencyNameSet{PluralTwo: "two francs", PluralOther: "francs"}); err != nil {
+        t.Fatal(err)
+    }
+    tests := []struct {
+        count int64
+        want  string
+    }{
+        {1, "francs"},
+        {2, "two francs"},
+        {3, "francs"},
+    }
+    for _, tt := range tests {
+        if got := CurrencyName("CHF", "x-test", tt.count); got != tt.want {
+            t.Errorf("CurrencyName(CHF, x-test, %d) = %q, want %q", tt.count, got, tt.want)
+        }
+    }
+}
+
+func TestLoadCurrencyNames(t *testing.T) {
+    tests := []struct {
+        name    string
+        input   string
+        wantErr bool
+    }{
+        {"valid", `{"x-load": {"GBP": {"one": "pound", "other": "pounds"}}}`, false},
+        {"unknown category", `{"x-load": {"GBP": {"several": "pounds"}}}`, true},
+        {"unknown currency", `{"x-load": {"XXX": {"one": "x"}}}`, true},
+        {"malformed", `{"x-load": `, true},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            err := LoadCurrencyNames(strings.NewReader(tt.input))
+            if (err != nil) != tt.wantErr {
+                t.Fatalf("LoadCurrencyNames() error = %v, wantErr %v", err, tt.wantErr)
+            }
+        })
+    }
+    if got := CurrencyName("GBP", "x-load", 2); got != "pounds" {
+        t.Errorf("CurrencyName(GBP, x-load, 2) = %q, want %q", got, "pounds")
+    }
+}
+
+func TestPluralCategoryText(t *testing.T) {
+    for category := PluralOther; category <= PluralMany; category++ {
+        text, err := category.MarshalText()
+        if err != nil {
+            t.Fatal(err)
+        }
+        var decoded PluralCategory
+        if err := decoded.UnmarshalText(text); err != nil {
+            t.Fatal(err)
+        }
+        if decoded != category {
+            t.Errorf("round trip of %v = %v", category, decoded)
+        }
+    }
+}
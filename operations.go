@@ -8,11 +8,36 @@ import (
 
 // New creates a Money instance from an integer amount
 func New(amount int64, currencyCode string) (*Money, error) {
-    currency, err := GetCurrency(currencyCode)
+    value, err := NewValue(amount, currencyCode)
     if err != nil {
         return nil, err
     }
-    return &Money{amount: amount, currency: currency}, nil
+    return &value, nil
+}
+
+// NewValue creates a Money value from an integer amount.
+// Money values are comparable with == and can be used as map keys.
+func NewValue(amount int64, currencyCode string) (Money, error) {
+    currency, err := GetCurrency(currencyCode)
+    if err != nil {
+        return Money{}, err
+    }
+    return Money{amount: amount, currency: internCurrency(currency.Code)}, nil
+}
+
+// Amount returns the amount in the currency's smallest unit
+func (m Money) Amount() int64 {
+    return m.amount
+}
+
+// CurrencyCode returns the ISO code of the Money's currency
+func (m Money) CurrencyCode() string {
+    return m.currency.code()
+}
+
+// Currency returns the current CurrencyMap details of the Money's currency
+func (m Money) Currency() Currency {
+    return m.currency.details()
 }
 
 // NewFromFloat creates a Money instance from a float64, applying DefaultRoundingMethod
@@ -27,58 +52,88 @@ func NewFromFloat(amount float64, currencyCode string) (*Money, error) {
     }
 
     factor := math.Pow(10, float64(currency.Precision))
-    scaledAmount := round(int64(amount*factor*10), DefaultRoundingMethod) / 10
-    return &Money{amount: scaledAmount, currency: currency}, nil
+    scaledAmount := round(int64(amount*factor*10), DefaultRoundingMethod)
+    return &Money{amount: scaledAmount, currency: internCurrency(currency.Code)}, nil
 }
 
 // Add adds two Money instances
 func (m *Money) Add(other *Money) (*Money, error) {
-    if m.currency != other.currency {
-        return nil, &CurrencyMismatchError{
-            Currency1: m.currency.Code,
-            Currency2: other.currency.Code,
-        }
+    result, err := m.AddValue(*other)
+    if err != nil {
+        return nil, err
     }
+    return &result, nil
+}
 
-    // Check for overflow
-    if (other.amount > 0 && m.amount > math.MaxInt64-other.amount) ||
-        (other.amount < 0 && m.amount < math.MinInt64-other.amount) {
-        return nil, &OverflowError{
-            Operation: "addition",
-            Amount1:   m.amount,
-            Amount2:   other.amount,
+// AddValue adds two Money values without allocating
+func (m Money) AddValue(other Money) (Money, error) {
+    if m.currency != other.currency {
+        return Money{}, &CurrencyMismatchError{
+            Currency1: m.currency.code(),
+            Currency2: other.currency.code(),
         }
     }
 
-    return &Money{amount: m.amount + other.amount, currency: m.currency}, nil
+    amount, err := addAmounts(m.amount, other.amount)
+    if err != nil {
+        return Money{}, err
+    }
+    return Money{amount: amount, currency: m.currency}, nil
 }
 
 // Subtract subtracts another Money from the current Money
 func (m *Money) Subtract(other *Money) (*Money, error) {
+    result, err := m.SubtractValue(*other)
+    if err != nil {
+        return nil, err
+    }
+    return &result, nil
+}
+
+// SubtractValue subtracts another Money value without allocating
+func (m Money) SubtractValue(other Money) (Money, error) {
     if m.currency != other.currency {
-        return nil, &CurrencyMismatchError{
-            Currency1: m.currency.Code,
-            Currency2: other.currency.Code,
+        return Money{}, &CurrencyMismatchError{
+            Currency1: m.currency.code(),
+            Currency2: other.currency.code(),
         }
     }
 
     // Check for overflow
     if (other.amount < 0 && m.amount > math.MaxInt64+other.amount) ||
         (other.amount > 0 && m.amount < math.MinInt64+other.amount) {
-        return nil, &OverflowError{
+        return Money{}, &OverflowError{
             Operation: "subtraction",
             Amount1:   m.amount,
             Amount2:   other.amount,
         }
     }
 
-    return &Money{amount: m.amount - other.amount, currency: m.currency}, nil
+    return Money{amount: m.amount - other.amount, currency: m.currency}, nil
+}
+
+// addAmounts adds two amounts in minor units, reporting overflow
+func addAmounts(a, b int64) (int64, error) {
+    if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+        return 0, &OverflowError{
+            Operation: "addition",
+            Amount1:   a,
+            Amount2:   b,
+        }
+    }
+    return a + b, nil
 }
 
 // Multiply multiplies Money by a factor and rounds the result
 func (m *Money) Multiply(factor float64) *Money {
-    scaledAmount := round(int64(float64(m.amount)*factor*10), DefaultRoundingMethod) / 10
-    return &Money{amount: scaledAmount, currency: m.currency}
+    result := m.MultiplyValue(factor)
+    return &result
+}
+
+// MultiplyValue multiplies a Money value by a factor and rounds the result
+func (m Money) MultiplyValue(factor float64) Money {
+    scaledAmount := round(int64(float64(m.amount)*factor*10), DefaultRoundingMethod)
+    return Money{amount: scaledAmount, currency: m.currency}
 }
 
 // ApplyPercentageDiscount applies a percentage discount to Money
@@ -95,49 +150,51 @@ func (m *Money) ApplyPercentageDiscount(percentage float64) (*Money, error) {
 
 // Equals checks if two Money instances are equal
 func (m *Money) Equals(other *Money) (bool, error) {
-    if m.currency != other.currency {
-        return false, &CurrencyMismatchError{
-            Currency1: m.currency.Code,
-            Currency2: other.currency.Code,
-        }
-    }
-    return m.amount == other.amount, nil
+    cmp, err := m.CompareValue(*other)
+    return cmp == 0 && err == nil, err
 }
 
 // GreaterThan checks if this Money is greater than another
 func (m *Money) GreaterThan(other *Money) (bool, error) {
-    if m.currency != other.currency {
-        return false, &CurrencyMismatchError{
-            Currency1: m.currency.Code,
-            Currency2: other.currency.Code,
-        }
-    }
-    return m.amount > other.amount, nil
+    cmp, err := m.CompareValue(*other)
+    return cmp > 0, err
 }
 
 // LessThan checks if this Money is less than another
 func (m *Money) LessThan(other *Money) (bool, error) {
+    cmp, err := m.CompareValue(*other)
+    return cmp < 0, err
+}
+
+// CompareValue returns -1, 0 or 1 as m is less than, equal to or greater than other
+func (m Money) CompareValue(other Money) (int, error) {
     if m.currency != other.currency {
-        return false, &CurrencyMismatchError{
-            Currency1: m.currency.Code,
-            Currency2: other.currency.Code,
+        return 0, &CurrencyMismatchError{
+            Currency1: m.currency.code(),
+            Currency2: other.currency.code(),
         }
     }
-    return m.amount < other.amount, nil
+    switch {
+    case m.amount < other.amount:
+        return -1, nil
+    case m.amount > other.amount:
+        return 1, nil
+    }
+    return 0, nil
 }
 
 // Abs returns the absolute value of Money
 func (m *Money) Abs() *Money {
+    result := m.AbsValue()
+    return &result
+}
+
+// AbsValue returns the absolute value of a Money value
+func (m Money) AbsValue() Money {
     if m.amount < 0 {
-        return &Money{
-            amount:   -m.amount,
-            currency: m.currency,
-        }
-    }
-    return &Money{
-        amount:   m.amount,
-        currency: m.currency,
+        return Money{amount: -m.amount, currency: m.currency}
     }
+    return m
 }
 
 // Sign returns:
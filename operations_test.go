@@ -0,0 +1,154 @@
+package money
+
+import (
+    "errors"
+    "math"
+    "testing"
+)
+
+// mustValue creates a Money value for tests, failing the test on error
+func mustValue(t *testing.T, amount int64, code string) Money {
+    t.Helper()
+    m, err := NewValue(amount, code)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return m
+}
+
+func TestAddValue(t *testing.T) {
+    tests := []struct {
+        name         string
+        a, b         Money
+        want         int64
+        wantMismatch bool
+        wantOverflow bool
+    }{
+        {"same currency", mustValue(t, 150, "EUR"), mustValue(t, 250, "EUR"), 400, false, false},
+        {"negative", mustValue(t, 150, "EUR"), mustValue(t, -250, "EUR"), -100, false, false},
+        {"mismatch", mustValue(t, 150, "EUR"), mustValue(t, 150, "USD"), 0, true, false},
+        {"overflow", mustValue(t, math.MaxInt64, "EUR"), mustValue(t, 1, "EUR"), 0, false, true},
+        {"underflow", mustValue(t, math.MinInt64, "EUR"), mustValue(t, -1, "EUR"), 0, false, true},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := tt.a.AddValue(tt.b)
+            var mismatch *CurrencyMismatchError
+            var overflow *OverflowError
+            if errors.As(err, &mismatch) != tt.wantMismatch || errors.As(err, &overflow) != tt.wantOverflow {
+                t.Fatalf("AddValue() error = %v", err)
+            }
+            if err == nil && (got.Amount() != tt.want || got.CurrencyCode() != tt.a.CurrencyCode()) {
+                t.Errorf("AddValue() = %d %s, want %d %s", got.Amount(), got.CurrencyCode(), tt.want, tt.a.CurrencyCode())
+            }
+        })
+    }
+}
+
+func TestSubtractValue(t *testing.T) {
+    tests := []struct {
+        name    string
+        a, b    Money
+        want    int64
+        wantErr bool
+    }{
+        {"same currency", mustValue(t, 250, "EUR"), mustValue(t, 150, "EUR"), 100, false},
+        {"below zero", mustValue(t, 150, "EUR"), mustValue(t, 250, "EUR"), -100, false},
+        {"mismatch", mustValue(t, 150, "EUR"), mustValue(t, 150, "USD"), 0, true},
+        {"overflow", mustValue(t, math.MinInt64, "EUR"), mustValue(t, 1, "EUR"), 0, true},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := tt.a.SubtractValue(tt.b)
+            if (err != nil) != tt.wantErr {
+                t.Fatalf("SubtractValue() error = %v, wantErr %v", err, tt.wantErr)
+            }
+            if err == nil && got.Amount() != tt.want {
+                t.Errorf("SubtractValue() = %d, want %d", got.Amount(), tt.want)
+            }
+        })
+    }
+}
+
+func TestCompareValue(t *testing.T) {
+    tests := []struct {
+        a, b    Money
+        want    int
+        wantErr bool
+    }{
+        {mustValue(t, 100, "USD"), mustValue(t, 200, "USD"), -1, false},
+        {mustValue(t, 200, "USD"), mustValue(t, 100, "USD"), 1, false},
+        {mustValue(t, 100, "USD"), mustValue(t, 100, "USD"), 0, false},
+        {mustValue(t, 100, "USD"), mustValue(t, 100, "EUR"), 0, true},
+    }
+    for _, tt := range tests {
+        got, err := tt.a.CompareValue(tt.b)
+        if (err != nil) != tt.wantErr || got != tt.want {
+            t.Errorf("CompareValue(%v, %v) = %d, %v, want %d", tt.a, tt.b, got, err, tt.want)
+        }
+    }
+}
+
+func TestMultiplyAndAbsValue(t *testing.T) {
+    tests := []struct {
+        amount int64
+        factor float64
+        want   int64
+    }{
+        {1000, 1.5, 1500},
+        {1000, 0.333, 333},
+        {-1000, 2, -2000},
+        {0, 3, 0},
+    }
+    for _, tt := range tests {
+        if got := mustValue(t, tt.amount, "USD").MultiplyValue(tt.factor); got.Amount() != tt.want {
+            t.Errorf("MultiplyValue(%d, %v) = %d, want %d", tt.amount, tt.factor, got.Amount(), tt.want)
+        }
+    }
+
+    if got := mustValue(t, -150, "USD").AbsValue(); got != mustValue(t, 150, "USD") {
+        t.Errorf("AbsValue(-150) = %v", got)
+    }
+}
+
+func TestValueCollections(t *testing.T) {
+    values := []Money{mustValue(t, 100, "EUR"), mustValue(t, -50, "EUR"), mustValue(t, 0, "EUR")}
+
+    sum, err := SumValues(values)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if sum != mustValue(t, 50, "EUR") {
+        t.Errorf("SumValues() = %v, want 50 EUR", sum)
+    }
+
+    sumTests := []struct {
+        name   string
+        values []Money
+    }{
+        {"empty", nil},
+        {"mismatch", []Money{mustValue(t, 100, "EUR"), mustValue(t, 100, "USD")}},
+        {"overflow", []Money{mustValue(t, math.MaxInt64, "EUR"), mustValue(t, 1, "EUR")}},
+    }
+    for _, tt := range sumTests {
+        if _, err := SumValues(tt.values); err == nil {
+            t.Errorf("SumValues() with %s values did not fail", tt.name)
+        }
+    }
+
+    positive := FilterValues(values, func(m Money) bool { return m.Amount() > 0 })
+    if len(positive) != 1 || positive[0].Amount() != 100 {
+        t.Errorf("FilterValues(positive) = %v", positive)
+    }
+
+    doubled, err := MapValues(values, func(m Money) Money { return m.MultiplyValue(2) })
+    if err != nil {
+        t.Fatal(err)
+    }
+    if doubled[1].Amount() != -100 {
+        t.Errorf("MapValues() = %v", doubled)
+    }
+    if _, err := MapValues(values, func(Money) Money { return mustValue(t, 1, "USD") }); err == nil {
+        t.Error("MapValues() allowed a transform to change the currency")
+    }
+}
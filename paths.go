@@ -0,0 +1,253 @@
+package money
+
+import (
+    "fmt"
+    "math/big"
+    "sort"
+    "strings"
+    "time"
+)
+
+// RateLister is implemented by rate sources that can enumerate the rates they know
+// as of a date, so that they can be combined into conversion paths
+type RateLister interface {
+    ListRates(date *time.Time) []RateInfo
+}
+
+// PathMetric selects how a PathConverter ranks candidate conversion paths
+type PathMetric int
+
+const (
+    PathFewestLegs PathMetric = iota // Fewest conversions
+    PathNewestData                   // Latest effective date of the oldest leg
+    PathPreference                   // Lowest total rank of the providers used, per Preference
+)
+
+// DefaultMaxPathLegs is the longest path a PathConverter considers when MaxLegs is unset
+const DefaultMaxPathLegs = 3
+
+// PathConverter finds conversions across the pairs known to its sources, for example
+// BRL→ARS directly, BRL→USD→JPY through USD, or CHF→EUR→SEK through EUR. Every known
+// rate can also be used in its inverse direction. Ties between paths are broken by
+// fewer legs, then fewer inverted rates, then newer data, so the same rates always
+// produce the same path.
+type PathConverter struct {
+    Sources    []RateLister
+    Metric     PathMetric
+    MaxLegs    int      // Longest path considered; DefaultMaxPathLegs when zero
+    Preference []string // Provider names from most to least preferred, used by PathPreference
+}
+
+// NewPathConverter creates a PathConverter over the given sources
+func NewPathConverter(metric PathMetric, sources ...RateLister) *PathConverter {
+    return &PathConverter{Sources: sources, Metric: metric}
+}
+
+// pathEdge is a known rate, or the inverse of one, in the conversion graph
+type pathEdge struct {
+    info    RateInfo
+    inverse bool
+}
+
+// pathCandidate is a path under evaluation with its ranking keys
+type pathCandidate struct {
+    legs     []RateInfo
+    oldest   time.Time
+    rank     int
+    inverses int
+}
+
+// FindPath returns the legs of the best path from one currency to another,
+// or a *NoPathError when the known pairs do not connect them
+func (c *PathConverter) FindPath(from, to string, date *time.Time) ([]RateInfo, error) {
+    if _, err := GetCurrency(from); err != nil {
+        return nil, err
+    }
+    if _, err := GetCurrency(to); err != nil {
+        return nil, err
+    }
+    if from == to {
+        return []RateInfo{}, nil
+    }
+
+    maxLegs := c.MaxLegs
+    if maxLegs <= 0 {
+        maxLegs = DefaultMaxPathLegs
+    }
+
+    graph := c.graph(date)
+    var best *pathCandidate
+    visited := map[string]bool{from: true}
+    var legs []pathEdge
+
+    var walk func(current string)
+    walk = func(current string) {
+        if current == to {
+            candidate := c.candidate(legs)
+            if best == nil || c.better(candidate, best) {
+                best = candidate
+            }
+            return
+        }
+        if len(legs) == maxLegs {
+            return
+        }
+        for _, edge := range graph[current] {
+            if visited[edge.info.To] {
+                continue
+            }
+            visited[edge.info.To] = true
+            legs = append(legs, edge)
+            walk(edge.info.To)
+            legs = legs[:len(legs)-1]
+            visited[edge.info.To] = false
+        }
+    }
+    walk(from)
+
+    if best == nil {
+        return nil, &NoPathError{From: from, To: to, MaxLegs: maxLegs}
+    }
+    return best.legs, nil
+}
+
+// graph builds the adjacency lists of known rates and their inverses. Edges are
+// ordered by target currency and provider, with stored rates before inverses, so
+// that the search does not depend on the order the sources list their rates.
+func (c *PathConverter) graph(date *time.Time) map[string][]pathEdge {
+    graph := make(map[string][]pathEdge)
+    for _, source := range c.Sources {
+        for _, info := range source.ListRates(date) {
+            if !info.Rate.IsPositive() {
+                continue
+            }
+            inverse := info
+            inverse.From, inverse.To, inverse.Rate = info.To, info.From, info.Rate.Inverse()
+            graph[info.From] = append(graph[info.From], pathEdge{info: info})
+            graph[inverse.From] = append(graph[inverse.From], pathEdge{info: inverse, inverse: true})
+        }
+    }
+    for _, edges := range graph {
+        sort.SliceStable(edges, func(i, j int) bool {
+            a, b := edges[i], edges[j]
+            switch {
+            case a.info.To != b.info.To:
+                return a.info.To < b.info.To
+            case a.info.Provider != b.info.Provider:
+                return a.info.Provider < b.info.Provider
+            case a.inverse != b.inverse:
+                return !a.inverse
+            case !a.info.EffectiveDate.Equal(b.info.EffectiveDate):
+                return a.info.EffectiveDate.After(b.info.EffectiveDate)
+            }
+            return a.info.Rate.Cmp(b.info.Rate) < 0
+        })
+    }
+    return graph
+}
+
+// candidate copies a path and computes its ranking keys
+func (c *PathConverter) candidate(legs []pathEdge) *pathCandidate {
+    result := &pathCandidate{legs: make([]RateInfo, len(legs))}
+    for i, leg := range legs {
+        result.legs[i] = leg.info
+        if i == 0 || leg.info.EffectiveDate.Before(result.oldest) {
+            result.oldest = leg.info.EffectiveDate
+        }
+        result.rank += c.providerRank(leg.info.Provider)
+        if leg.inverse {
+            result.inverses++
+        }
+    }
+    return result
+}
+
+// providerRank returns the position of a provider in Preference, ranking unknown providers last
+func (c *PathConverter) providerRank(provider string) int {
+    for i, name := range c.Preference {
+        if name == provider {
+            return i
+        }
+    }
+    return len(c.Preference)
+}
+
+// better reports whether path a ranks above path b under the converter's metric
+func (c *PathConverter) better(a, b *pathCandidate) bool {
+    switch c.Metric {
+    case PathNewestData:
+        if !a.oldest.Equal(b.oldest) {
+            return a.oldest.After(b.oldest)
+        }
+    case PathPreference:
+        if a.rank != b.rank {
+            return a.rank < b.rank
+        }
+    }
+    if len(a.legs) != len(b.legs) {
+        return len(a.legs) < len(b.legs)
+    }
+    if a.inverses != b.inverses {
+        return a.inverses < b.inverses
+    }
+    return a.oldest.After(b.oldest)
+}
+
+// GetRate implements CurrencyConverter
+func (c *PathConverter) GetRate(from, to string, date *time.Time) (float64, error) {
+    info, err := c.GetRateInfo(from, to, date)
+    if err != nil {
+        return 0, err
+    }
+    return info.Rate.Float64(), nil
+}
+
+// GetExactRate implements RateProvider
+func (c *PathConverter) GetExactRate(from, to string, date *time.Time) (Rate, error) {
+    info, err := c.GetRateInfo(from, to, date)
+    if err != nil {
+        return Rate{}, err
+    }
+    return info.Rate, nil
+}
+
+// GetRateInfo implements RateInfoProvider. The rate is the exact product of the path's
+// legs, the provider describes the route taken, and the effective date is that of the
+// oldest leg. Use FindPath or ConvertViaPath to obtain the individual legs.
+func (c *PathConverter) GetRateInfo(from, to string, date *time.Time) (RateInfo, error) {
+    legs, err := c.FindPath(from, to, date)
+    if err != nil {
+        return RateInfo{}, err
+    }
+
+    info := RateInfo{From: from, To: to, Rate: Rate{r: big.NewRat(1, 1)}}
+    route := []string{from}
+    for i, leg := range legs {
+        info.Rate = info.Rate.Mul(leg.Rate)
+        if i == 0 || leg.EffectiveDate.Before(info.EffectiveDate) {
+            info.EffectiveDate = leg.EffectiveDate
+        }
+        route = append(route, leg.To)
+    }
+    info.Provider = fmt.Sprintf("path %s", strings.Join(route, "→"))
+    return info, nil
+}
+
+// ProviderName implements NamedProvider
+func (c *PathConverter) ProviderName() string {
+    return "PathConverter"
+}
+
+// ConvertViaPath converts Money along the best path found by converter and returns
+// an audit record with one leg per conversion step. The rates are multiplied exactly
+// and the result is rounded once.
+func (m *Money) ConvertViaPath(converter *PathConverter, targetCurrency string, date *time.Time) (*ConversionResult, error) {
+    legs, err := converter.FindPath(m.currency.code(), targetCurrency, date)
+    if err != nil {
+        return nil, err
+    }
+    if len(legs) == 0 {
+        return m.ConvertWithResult(targetCurrency, Rate{r: big.NewRat(1, 1)})
+    }
+    return m.convertWithLegs(targetCurrency, date, legs)
+}
@@ -0,0 +1,147 @@
+package money
+
+import (
+    "errors"
+    "testing"
+    "time"
+)
+
+// pathSources returns a "bank" table with rates through USD and EUR, and a "feed"
+// table with an older direct BRL/JPY rate and a USD/EUR rate opposite to the bank's
+func pathSources(t *testing.T) (bank, feed *RateTable) {
+    t.Helper()
+    bank, feed = &RateTable{Name: "bank"}, &RateTable{Name: "feed"}
+    for _, r := range []struct {
+        table    *RateTable
+        from, to string
+        rate     string
+        date     time.Time
+    }{
+        {bank, "BRL", "USD", "0.2", day(2024, 1, 5)},
+        {bank, "USD", "JPY", "150", day(2024, 1, 5)},
+        {bank, "EUR", "USD", "1.1", day(2024, 1, 5)},
+        {bank, "CHF", "EUR", "1.05", day(2024, 1, 5)},
+        {bank, "EUR", "SEK", "11", day(2024, 1, 6)},
+        {feed, "BRL", "JPY", "30.5", day(2024, 1, 1)},
+        {feed, "USD", "EUR", "0.9", day(2024, 1, 1)},
+    } {
+        if err := r.table.SetRate(r.from, r.to, MustParseRate(r.rate), r.date); err != nil {
+            t.Fatal(err)
+        }
+    }
+    return bank, feed
+}
+
+func TestPathConverterFindPath(t *testing.T) {
+    bank, feed := pathSources(t)
+
+    tests := []struct {
+        name       string
+        metric     PathMetric
+        maxLegs    int
+        preference []string
+        from, to   string
+        want       string // Provider route reported by GetRateInfo
+        wantRate   string
+        wantDate   time.Time
+    }{
+        {"fewest legs takes the direct rate", PathFewestLegs, 0, nil, "BRL", "JPY", "path BRL→JPY", "30.5", day(2024, 1, 1)},
+        {"inverse of a direct rate", PathFewestLegs, 0, nil, "JPY", "BRL", "path JPY→BRL", "2/61", day(2024, 1, 1)},
+        {"newest data prefers fresher legs", PathNewestData, 0, nil, "BRL", "JPY", "path BRL→USD→JPY", "30", day(2024, 1, 5)},
+        {"preference ranks providers", PathPreference, 0, []string{"bank", "feed"}, "BRL", "JPY", "path BRL→USD→JPY", "30", day(2024, 1, 5)},
+        {"preference for the other provider", PathPreference, 0, []string{"feed", "bank"}, "BRL", "JPY", "path BRL→JPY", "30.5", day(2024, 1, 1)},
+        {"through a reference currency", PathFewestLegs, 0, nil, "CHF", "SEK", "path CHF→EUR→SEK", "11.55", day(2024, 1, 5)},
+        {"three legs", PathFewestLegs, 0, nil, "CHF", "JPY", "path CHF→EUR→USD→JPY", "173.25", day(2024, 1, 5)},
+        {"stored rate preferred over inverse", PathFewestLegs, 0, nil, "USD", "EUR", "path USD→EUR", "0.9", day(2024, 1, 1)},
+        {"stored rate preferred in the other direction", PathFewestLegs, 0, nil, "EUR", "USD", "path EUR→USD", "1.1", day(2024, 1, 5)},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            for _, sources := range [][]RateLister{{bank, feed}, {feed, bank}} {
+                converter := NewPathConverter(tt.metric, sources...)
+                converter.MaxLegs = tt.maxLegs
+                converter.Preference = tt.preference
+                info, err := converter.GetRateInfo(tt.from, tt.to, nil)
+                if err != nil {
+                    t.Fatal(err)
+                }
+                if info.Provider != tt.want || info.Rate.Cmp(MustParseRate(tt.wantRate)) != 0 || !info.EffectiveDate.Equal(tt.wantDate) {
+                    t.Errorf("GetRateInfo() = %s %v effective %v, want %s %s effective %v",
+                        info.Provider, info.Rate, info.EffectiveDate, tt.want, tt.wantRate, tt.wantDate)
+                }
+            }
+        })
+    }
+}
+
+func TestPathConverterNoPath(t *testing.T) {
+    bank, feed := pathSources(t)
+
+    tests := []struct {
+        name     string
+        maxLegs  int
+        from, to string
+        noPath   bool
+    }{
+        {"beyond MaxLegs", 1, "CHF", "SEK", true},
+        {"beyond the default MaxLegs", 0, "SEK", "BRL", false},
+        {"disconnected currency", 0, "GBP", "USD", true},
+        {"unknown currency", 0, "XXX", "USD", false},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            converter := &PathConverter{Sources: []RateLister{bank, feed}, MaxLegs: tt.maxLegs}
+            legs, err := converter.FindPath(tt.from, tt.to, nil)
+            var noPath *NoPathError
+            if err == nil {
+                if tt.noPath || tt.from == "XXX" {
+                    t.Fatalf("FindPath() = %v, want an error", legs)
+                }
+                return
+            }
+            if errors.As(err, &noPath) != tt.noPath {
+                t.Errorf("FindPath() error = %v, NoPathError expected: %v", err, tt.noPath)
+            }
+        })
+    }
+}
+
+func TestPathConverterHonoursDate(t *testing.T) {
+    bank, feed := pathSources(t)
+    converter := NewPathConverter(PathFewestLegs, bank, feed)
+
+    // On January 3 only the feed's rates are effective
+    date := day(2024, 1, 3)
+    if _, err := converter.FindPath("CHF", "SEK", &date); err == nil {
+        t.Error("FindPath() used rates not yet effective")
+    }
+    info, err := converter.GetRateInfo("EUR", "USD", &date)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if info.Rate.Cmp(MustParseRate("10/9")) != 0 {
+        t.Errorf("GetRateInfo() = %v, want the inverse of the feed's rate", info.Rate)
+    }
+}
+
+func TestConvertViaPath(t *testing.T) {
+    bank, feed := pathSources(t)
+    converter := &PathConverter{Sources: []RateLister{bank, feed}, Metric: PathPreference, Preference: []string{"bank"}}
+
+    result, err := mustNew(t, 333, "BRL").ConvertViaPath(converter, "JPY", nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    // 3.33 BRL × 0.2 × 150 = 99.9 JPY, rounded once
+    if result.Target.Amount() != 100 || len(result.Legs) != 2 || result.Legs[0].Provider != "bank" || result.Legs[0].Amount.Amount() != 67 {
+        t.Errorf("ConvertViaPath() = %d with legs %+v", result.Target.Amount(), result.Legs)
+    }
+
+    same, err := mustNew(t, 333, "USD").ConvertViaPath(converter, "USD", nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if same.Target.Amount() != 333 {
+        t.Errorf("ConvertViaPath() to the same currency = %d, want 333", same.Target.Amount())
+    }
+}
@@ -0,0 +1,152 @@
+package money
+
+import (
+    "fmt"
+    "math"
+    "sync"
+)
+
+// ProcessorCurrencyRule describes how a payment processor expects amounts in one currency
+type ProcessorCurrencyRule struct {
+    Exponent    int   // Decimal places implied by the processor's integer amount
+    Granularity int64 // Processor amounts must be a multiple of this value; 0 or 1 allows any
+}
+
+// ProcessorProfile maps Money to and from the integer amounts a payment processor expects.
+// Currencies without a rule use their ISO precision from CurrencyMap.
+type ProcessorProfile struct {
+    Name     string
+    Rules    map[string]ProcessorCurrencyRule
+    Rounding RoundingMethod // Applied when the processor needs fewer decimals or coarser granularity
+}
+
+// processorsMu guards processorProfiles
+var processorsMu sync.RWMutex
+
+// processorProfiles holds the built-in and registered processor profiles by name
+var processorProfiles = map[string]ProcessorProfile{
+    // Plain ISO 4217 minor units
+    "iso": {Name: "iso"},
+
+    // Stripe sends ISK and HUF in hundredths, requires whole-unit HUF and TWD amounts,
+    // and only accepts three-decimal amounts rounded to the nearest 10
+    "stripe": {
+        Name: "stripe",
+        Rules: map[string]ProcessorCurrencyRule{
+            "ISK": {Exponent: 2, Granularity: 100},
+            "HUF": {Exponent: 2, Granularity: 100},
+            "TWD": {Exponent: 2, Granularity: 100},
+            "BHD": {Exponent: 3, Granularity: 10},
+            "JOD": {Exponent: 3, Granularity: 10},
+            "KWD": {Exponent: 3, Granularity: 10},
+            "OMR": {Exponent: 3, Granularity: 10},
+            "TND": {Exponent: 3, Granularity: 10},
+        },
+    },
+}
+
+// RegisterProcessorProfile adds or replaces a processor profile
+func RegisterProcessorProfile(profile ProcessorProfile) error {
+    if profile.Name == "" {
+        return &ValidationError{
+            Field:   "processor profile",
+            Message: "profile name cannot be empty",
+        }
+    }
+    for code, rule := range profile.Rules {
+        if rule.Exponent < 0 || rule.Exponent > 18 || rule.Granularity < 0 {
+            return &ValidationError{
+                Field:   "processor profile",
+                Message: fmt.Sprintf("invalid rule for %s in profile %s", code, profile.Name),
+            }
+        }
+    }
+    processorsMu.Lock()
+    defer processorsMu.Unlock()
+    processorProfiles[profile.Name] = profile
+    return nil
+}
+
+// GetProcessorProfile retrieves a processor profile by name
+func GetProcessorProfile(name string) (ProcessorProfile, error) {
+    processorsMu.RLock()
+    profile, exists := processorProfiles[name]
+    processorsMu.RUnlock()
+    if !exists {
+        return ProcessorProfile{}, &ValidationError{
+            Field:   "processor profile",
+            Message: fmt.Sprintf("processor profile %s not found", name),
+        }
+    }
+    return profile, nil
+}
+
+// rule returns the processor rule for a currency, defaulting to its ISO precision
+func (p ProcessorProfile) rule(currency Currency) ProcessorCurrencyRule {
+    if rule, ok := p.Rules[currency.Code]; ok {
+        return rule
+    }
+    return ProcessorCurrencyRule{Exponent: currency.Precision}
+}
+
+// ToMinorUnits returns the integer amount the processor expects for m,
+// rounding with the profile's Rounding method where the processor is coarser
+func (p ProcessorProfile) ToMinorUnits(m *Money) (int64, error) {
+    currency := m.currency.details()
+    rule := p.rule(currency)
+    amount := m.amount
+
+    switch diff := rule.Exponent - currency.Precision; {
+    case diff > 0:
+        factor := int64(math.Pow10(diff))
+        if amount > math.MaxInt64/factor || amount < math.MinInt64/factor {
+            return 0, &OverflowError{
+                Operation: "processor conversion",
+                Amount1:   amount,
+                Amount2:   factor,
+            }
+        }
+        amount *= factor
+    case diff < 0:
+        amount = divRound(amount, int64(math.Pow10(-diff)), p.Rounding)
+    }
+
+    if rule.Granularity > 1 {
+        amount = divRound(amount, rule.Granularity, p.Rounding) * rule.Granularity
+    }
+    return amount, nil
+}
+
+// FromMinorUnits creates Money from an integer amount in the processor's representation.
+// Amounts with more precision than the currency supports are rejected.
+func (p ProcessorProfile) FromMinorUnits(amount int64, currencyCode string) (*Money, error) {
+    currency, err := GetCurrency(currencyCode)
+    if err != nil {
+        return nil, err
+    }
+    rule := p.rule(currency)
+
+    switch diff := rule.Exponent - currency.Precision; {
+    case diff > 0:
+        factor := int64(math.Pow10(diff))
+        if amount%factor != 0 {
+            return nil, &ValidationError{
+                Field:   "amount",
+                Message: fmt.Sprintf("%d cannot be represented in %s with %d decimal places", amount, currency.Code, currency.Precision),
+            }
+        }
+        amount /= factor
+    case diff < 0:
+        factor := int64(math.Pow10(-diff))
+        if amount > math.MaxInt64/factor || amount < math.MinInt64/factor {
+            return nil, &OverflowError{
+                Operation: "processor conversion",
+                Amount1:   amount,
+                Amount2:   factor,
+            }
+        }
+        amount *= factor
+    }
+
+    return &Money{amount: amount, currency: internCurrency(currency.Code)}, nil
+}
@@ -0,0 +1,176 @@
+package money
+
+import (
+    "fmt"
+    "math"
+    "sync"
+    "testing"
+)
+
+func TestProcessorToMinorUnits(t *testing.T) {
+    tests := []struct {
+        profile string
+        amount  int64
+        code    string
+        want    int64
+    }{
+        {"iso", 1234, "USD", 1234},
+        {"iso", 1500, "JPY", 1500},
+        {"iso", 1234, "KWD", 1234},
+        {"stripe", 1234, "USD", 1234},
+        {"stripe", 1500, "JPY", 1500},
+        {"stripe", 1500, "ISK", 150000},
+        {"stripe", -1500, "ISK", -150000},
+        {"stripe", 12345, "HUF", 12300},
+        {"stripe", 12350, "HUF", 12400},
+        {"stripe", 12349, "TWD", 12300},
+        {"stripe", 1234, "KWD", 1230},
+        {"stripe", 1235, "KWD", 1240},
+        {"stripe", 1235, "OMR", 1240},
+        {"stripe", -1235, "BHD", -1240},
+    }
+    for _, tt := range tests {
+        t.Run(fmt.Sprintf("%s %d %s", tt.profile, tt.amount, tt.code), func(t *testing.T) {
+            profile, err := GetProcessorProfile(tt.profile)
+            if err != nil {
+                t.Fatal(err)
+            }
+            got, err := profile.ToMinorUnits(mustNew(t, tt.amount, tt.code))
+            if err != nil {
+                t.Fatal(err)
+            }
+            if got != tt.want {
+                t.Errorf("ToMinorUnits() = %d, want %d", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestProcessorToMinorUnitsRounding(t *testing.T) {
+    tests := []struct {
+        method RoundingMethod
+        amount int64
+        want   int64
+    }{
+        {RoundHalfUp, 1235, 1240},
+        {RoundHalfDown, 1235, 1230},
+        {RoundHalfEven, 1235, 1240},
+        {RoundHalfEven, 1245, 1240},
+        {RoundUp, 1231, 1240},
+        {RoundDown, 1239, 1230},
+    }
+    for _, tt := range tests {
+        t.Run(fmt.Sprintf("%v %d", tt.method, tt.amount), func(t *testing.T) {
+            profile := ProcessorProfile{
+                Name:     "test",
+                Rules:    map[string]ProcessorCurrencyRule{"KWD": {Exponent: 3, Granularity: 10}},
+                Rounding: tt.method,
+            }
+            got, err := profile.ToMinorUnits(mustNew(t, tt.amount, "KWD"))
+            if err != nil {
+                t.Fatal(err)
+            }
+            if got != tt.want {
+                t.Errorf("ToMinorUnits() = %d, want %d", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestProcessorFromMinorUnits(t *testing.T) {
+    tests := []struct {
+        profile string
+        amount  int64
+        code    string
+        want    int64
+        wantErr bool
+    }{
+        {"iso", 1234, "USD", 1234, false},
+        {"stripe", 150000, "ISK", 1500, false},
+        {"stripe", 150050, "ISK", 0, true},
+        {"stripe", 12300, "HUF", 12300, false},
+        {"stripe", 1230, "KWD", 1230, false},
+        {"stripe", 100, "XXX", 0, true},
+    }
+    for _, tt := range tests {
+        t.Run(fmt.Sprintf("%s %d %s", tt.profile, tt.amount, tt.code), func(t *testing.T) {
+            profile, err := GetProcessorProfile(tt.profile)
+            if err != nil {
+                t.Fatal(err)
+            }
+            m, err := profile.FromMinorUnits(tt.amount, tt.code)
+            if (err != nil) != tt.wantErr {
+                t.Fatalf("FromMinorUnits() error = %v, wantErr %v", err, tt.wantErr)
+            }
+            if err == nil && m.Amount() != tt.want {
+                t.Errorf("FromMinorUnits() = %d, want %d", m.Amount(), tt.want)
+            }
+        })
+    }
+}
+
+func TestProcessorOverflow(t *testing.T) {
+    profile := ProcessorProfile{
+        Name:  "test",
+        Rules: map[string]ProcessorCurrencyRule{"USD": {Exponent: 4}, "KWD": {Exponent: 2}},
+    }
+    if _, err := profile.ToMinorUnits(mustNew(t, math.MaxInt64/10, "USD")); err == nil {
+        t.Error("ToMinorUnits() did not report overflow")
+    }
+    if _, err := profile.FromMinorUnits(math.MinInt64/2, "KWD"); err == nil {
+        t.Error("FromMinorUnits() did not report overflow")
+    }
+}
+
+func TestRegisterProcessorProfile(t *testing.T) {
+    tests := []struct {
+        name    string
+        profile ProcessorProfile
+        wantErr bool
+    }{
+        {"valid", ProcessorProfile{Name: "test-valid", Rules: map[string]ProcessorCurrencyRule{"JPY": {Exponent: 2}}}, false},
+        {"empty name", ProcessorProfile{}, true},
+        {"negative exponent", ProcessorProfile{Name: "test-bad", Rules: map[string]ProcessorCurrencyRule{"JPY": {Exponent: -1}}}, true},
+        {"exponent too large", ProcessorProfile{Name: "test-bad", Rules: map[string]ProcessorCurrencyRule{"JPY": {Exponent: 19}}}, true},
+        {"negative granularity", ProcessorProfile{Name: "test-bad", Rules: map[string]ProcessorCurrencyRule{"JPY": {Granularity: -10}}}, true},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            err := RegisterProcessorProfile(tt.profile)
+            if (err != nil) != tt.wantErr {
+                t.Fatalf("RegisterProcessorProfile() error = %v, wantErr %v", err, tt.wantErr)
+            }
+        })
+    }
+
+    if _, err := GetProcessorProfile("test-bad"); err == nil {
+        t.Error("an invalid profile was registered")
+    }
+    profile, err := GetProcessorProfile("test-valid")
+    if err != nil {
+        t.Fatal(err)
+    }
+    if got, _ := profile.ToMinorUnits(mustNew(t, 15, "JPY")); got != 1500 {
+        t.Errorf("ToMinorUnits() with a registered profile = %d, want 1500", got)
+    }
+}
+
+func TestRegisterProcessorProfileConcurrent(t *testing.T) {
+    var wg sync.WaitGroup
+    for i := 0; i < 8; i++ {
+        wg.Add(2)
+        go func(i int) {
+            defer wg.Done()
+            if err := RegisterProcessorProfile(ProcessorProfile{Name: fmt.Sprintf("test-concurrent-%d", i)}); err != nil {
+                t.Error(err)
+            }
+        }(i)
+        go func() {
+            defer wg.Done()
+            if _, err := GetProcessorProfile("stripe"); err != nil {
+                t.Error(err)
+            }
+        }()
+    }
+    wg.Wait()
+}
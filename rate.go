@@ -0,0 +1,247 @@
+package money
+
+import (
+    "fmt"
+    "math"
+    "math/big"
+    "strconv"
+    "time"
+)
+
+// Rate is an exact exchange rate held as a rational number, so decimal rates such as
+// 1.083700 are represented without binary floating point error. Rates are immutable;
+// the zero Rate is zero.
+type Rate struct {
+    r *big.Rat
+}
+
+// NewRate creates a Rate from a fraction num/den
+func NewRate(num, den int64) (Rate, error) {
+    if den == 0 {
+        return Rate{}, &ValidationError{
+            Field:   "rate",
+            Message: "rate denominator cannot be zero",
+        }
+    }
+    return Rate{r: big.NewRat(num, den)}, nil
+}
+
+// ParseRate parses a decimal ("1.0837", "1.5e-3") or fractional ("3/4") rate exactly
+func ParseRate(s string) (Rate, error) {
+    r, ok := new(big.Rat).SetString(s)
+    if !ok {
+        return Rate{}, &ValidationError{
+            Field:   "rate",
+            Message: fmt.Sprintf("%q is not a valid rate", s),
+        }
+    }
+    return Rate{r: r}, nil
+}
+
+// MustParseRate is like ParseRate but panics if s is not a valid rate.
+// It is intended for rates written as constants in code.
+func MustParseRate(s string) Rate {
+    rate, err := ParseRate(s)
+    if err != nil {
+        panic(err)
+    }
+    return rate
+}
+
+// RateFromFloat converts a float64 rate using its shortest decimal representation,
+// so 1.0837 becomes exactly 1.0837 rather than the nearest binary fraction
+func RateFromFloat(f float64) (Rate, error) {
+    if math.IsNaN(f) || math.IsInf(f, 0) {
+        return Rate{}, &ValidationError{
+            Field:   "rate",
+            Message: fmt.Sprintf("%v is not a valid rate", f),
+        }
+    }
+    return ParseRate(strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+// rat returns the underlying value, treating the zero Rate as 0.
+// The result must not be modified.
+func (r Rate) rat() *big.Rat {
+    if r.r == nil {
+        return new(big.Rat)
+    }
+    return r.r
+}
+
+// Rat returns a copy of the rate as a big.Rat
+func (r Rate) Rat() *big.Rat {
+    return new(big.Rat).Set(r.rat())
+}
+
+// Float64 returns the nearest float64 to the rate
+func (r Rate) Float64() float64 {
+    f, _ := r.rat().Float64()
+    return f
+}
+
+// Mul returns the product of two rates, e.g. chaining EUR→USD and USD→JPY
+func (r Rate) Mul(other Rate) Rate {
+    return Rate{r: new(big.Rat).Mul(r.rat(), other.rat())}
+}
+
+// Inverse returns 1/r, the rate for the opposite direction. The inverse of zero is zero.
+func (r Rate) Inverse() Rate {
+    if r.Sign() == 0 {
+        return Rate{}
+    }
+    return Rate{r: new(big.Rat).Inv(r.rat())}
+}
+
+// Round returns the rate rounded to a number of decimal places with the given method.
+// Negative places are treated as zero.
+func (r Rate) Round(places int, method RoundingMethod) Rate {
+    if places < 0 {
+        places = 0
+    }
+    scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(places)), nil))
+    scaled := new(big.Rat).Mul(r.rat(), scale)
+    rounded := new(big.Rat).SetInt(roundRatInt(scaled, method))
+    return Rate{r: rounded.Quo(rounded, scale)}
+}
+
+// Cmp compares two rates and returns -1, 0 or 1
+func (r Rate) Cmp(other Rate) int {
+    return r.rat().Cmp(other.rat())
+}
+
+// Sign returns -1, 0 or 1 depending on the sign of the rate
+func (r Rate) Sign() int {
+    return r.rat().Sign()
+}
+
+// IsPositive returns true if the rate is greater than zero
+func (r Rate) IsPositive() bool {
+    return r.Sign() > 0
+}
+
+// String returns the rate as a plain decimal when it terminates (e.g. "1.0837"),
+// and as a fraction otherwise (e.g. "1/3")
+func (r Rate) String() string {
+    x := r.rat()
+    if places, ok := decimalPlaces(x.Denom()); ok {
+        return x.FloatString(places)
+    }
+    return x.String()
+}
+
+// MarshalText implements encoding.TextMarshaler using String
+func (r Rate) MarshalText() ([]byte, error) {
+    return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseRate
+func (r *Rate) UnmarshalText(text []byte) error {
+    parsed, err := ParseRate(string(text))
+    if err != nil {
+        return err
+    }
+    *r = parsed
+    return nil
+}
+
+// decimalPlaces reports how many decimal places are needed to write 1/den exactly,
+// or false if the expansion does not terminate
+func decimalPlaces(den *big.Int) (int, bool) {
+    d := new(big.Int).Set(den)
+    two, five := big.NewInt(2), big.NewInt(5)
+    mod := new(big.Int)
+    twos, fives := 0, 0
+    for {
+        if _, m := new(big.Int).QuoRem(d, two, mod); m.Sign() != 0 {
+            break
+        }
+        d.Quo(d, two)
+        twos++
+    }
+    for {
+        if _, m := new(big.Int).QuoRem(d, five, mod); m.Sign() != 0 {
+            break
+        }
+        d.Quo(d, five)
+        fives++
+    }
+    if d.Cmp(big.NewInt(1)) != 0 {
+        return 0, false
+    }
+    if twos > fives {
+        return twos, true
+    }
+    return fives, true
+}
+
+// RateProvider supplies exact exchange rates. It is the exact counterpart of
+// CurrencyConverter; see ProviderFromConverter and ConverterFromProvider.
+type RateProvider interface {
+    GetExactRate(from, to string, date *time.Time) (Rate, error)
+}
+
+// converterRateProvider adapts a CurrencyConverter to RateProvider
+type converterRateProvider struct {
+    converter CurrencyConverter
+}
+
+// ProviderFromConverter adapts a float64 CurrencyConverter into a RateProvider.
+// Each rate is taken at its shortest decimal representation.
+func ProviderFromConverter(converter CurrencyConverter) RateProvider {
+    if provider, ok := converter.(RateProvider); ok {
+        return provider
+    }
+    return &converterRateProvider{converter: converter}
+}
+
+func (p *converterRateProvider) GetExactRate(from, to string, date *time.Time) (Rate, error) {
+    f, err := p.converter.GetRate(from, to, date)
+    if err != nil {
+        return Rate{}, err
+    }
+    return RateFromFloat(f)
+}
+
+// ProviderName implements NamedProvider using the adapted converter's name
+func (p *converterRateProvider) ProviderName() string {
+    return providerName(p.converter)
+}
+
+// providerConverter adapts a RateProvider to CurrencyConverter
+type providerConverter struct {
+    provider RateProvider
+}
+
+// ConverterFromProvider adapts a RateProvider into a float64 CurrencyConverter
+func ConverterFromProvider(provider RateProvider) CurrencyConverter {
+    if converter, ok := provider.(CurrencyConverter); ok {
+        return converter
+    }
+    return &providerConverter{provider: provider}
+}
+
+func (c *providerConverter) GetRate(from, to string, date *time.Time) (float64, error) {
+    rate, err := c.provider.GetExactRate(from, to, date)
+    if err != nil {
+        return 0, err
+    }
+    return rate.Float64(), nil
+}
+
+// ProviderName implements NamedProvider using the adapted provider's name
+func (c *providerConverter) ProviderName() string {
+    return providerName(c.provider)
+}
+
+// defaultRateProvider returns DefaultRateProvider, or an adapter over DefaultConverter
+// when only that is configured. It returns nil if neither is set.
+func defaultRateProvider() RateProvider {
+    if DefaultRateProvider != nil {
+        return DefaultRateProvider
+    }
+    if DefaultConverter != nil {
+        return ProviderFromConverter(DefaultConverter)
+    }
+    return nil
+}
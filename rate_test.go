@@ -0,0 +1,221 @@
+package money
+
+import (
+    "math"
+    "testing"
+    "time"
+)
+
+// mapConverter is a CurrencyConverter over a fixed set of float64 rates
+type mapConverter map[CurrencyPair]float64
+
+func (c mapConverter) GetRate(from, to string, date *time.Time) (float64, error) {
+    if rate, ok := c[CurrencyPair{From: from, To: to}]; ok {
+        return rate, nil
+    }
+    return 0, &RateNotFoundError{From: from, To: to, Date: date}
+}
+
+func TestParseRate(t *testing.T) {
+    tests := []struct {
+        input   string
+        want    string
+        wantErr bool
+    }{
+        {"1.0837", "1.0837", false},
+        {"1.083700", "1.0837", false},
+        {"1.5e-3", "0.0015", false},
+        {"3/4", "0.75", false},
+        {"1/3", "1/3", false},
+        {"-2", "-2", false},
+        {"0", "0", false},
+        {"", "", true},
+        {"1,5", "", true},
+        {"abc", "", true},
+    }
+    for _, tt := range tests {
+        t.Run(tt.input, func(t *testing.T) {
+            got, err := ParseRate(tt.input)
+            if (err != nil) != tt.wantErr {
+                t.Fatalf("ParseRate(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+            }
+            if err == nil && got.String() != tt.want {
+                t.Errorf("ParseRate(%q) = %s, want %s", tt.input, got, tt.want)
+            }
+        })
+    }
+}
+
+func TestNewRate(t *testing.T) {
+    if rate, err := NewRate(6, 8); err != nil || rate.String() != "0.75" {
+        t.Errorf("NewRate(6, 8) = %v, %v, want 0.75", rate, err)
+    }
+    if _, err := NewRate(1, 0); err == nil {
+        t.Error("NewRate(1, 0) did not fail")
+    }
+}
+
+func TestRateFromFloat(t *testing.T) {
+    tests := []struct {
+        input   float64
+        want    string
+        wantErr bool
+    }{
+        {1.0837, "1.0837", false},
+        {0.1, "0.1", false},
+        {150, "150", false},
+        {1e-9, "0.000000001", false},
+        {math.NaN(), "", true},
+        {math.Inf(1), "", true},
+    }
+    for _, tt := range tests {
+        got, err := RateFromFloat(tt.input)
+        if (err != nil) != tt.wantErr {
+            t.Fatalf("RateFromFloat(%v) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+        }
+        if err == nil && got.String() != tt.want {
+            t.Errorf("RateFromFloat(%v) = %s, want %s", tt.input, got, tt.want)
+        }
+    }
+}
+
+func TestRateArithmetic(t *testing.T) {
+    tests := []struct {
+        name string
+        got  Rate
+        want string
+    }{
+        {"mul", MustParseRate("1.1").Mul(MustParseRate("150")), "165"},
+        {"inverse", MustParseRate("1.25").Inverse(), "0.8"},
+        {"inverse of zero", Rate{}.Inverse(), "0"},
+        {"zero value", Rate{}, "0"},
+        {"round half up", MustParseRate("1.08375").Round(4, RoundHalfUp), "1.0838"},
+        {"round half even", MustParseRate("1.08375").Round(4, RoundHalfEven), "1.0838"},
+        {"round half even down", MustParseRate("1.08365").Round(4, RoundHalfEven), "1.0836"},
+        {"round down", MustParseRate("2/3").Round(3, RoundDown), "0.666"},
+        {"round negative places", MustParseRate("2.5").Round(-1, RoundHalfDown), "2"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if tt.got.String() != tt.want {
+                t.Errorf("got %s, want %s", tt.got, tt.want)
+            }
+        })
+    }
+
+    if MustParseRate("1.10").Cmp(MustParseRate("11/10")) != 0 {
+        t.Error("1.10 and 11/10 compare unequal")
+    }
+    if MustParseRate("-1").IsPositive() || (Rate{}).IsPositive() || !MustParseRate("0.001").IsPositive() {
+        t.Error("IsPositive() is wrong")
+    }
+}
+
+func TestRateText(t *testing.T) {
+    for _, input := range []string{"1.0837", "1/3", "0"} {
+        text, err := MustParseRate(input).MarshalText()
+        if err != nil {
+            t.Fatal(err)
+        }
+        var decoded Rate
+        if err := decoded.UnmarshalText(text); err != nil {
+            t.Fatal(err)
+        }
+        if decoded.Cmp(MustParseRate(input)) != 0 {
+            t.Errorf("round trip of %s = %s", input, decoded)
+        }
+    }
+    var rate Rate
+    if err := rate.UnmarshalText([]byte("x")); err == nil {
+        t.Error("UnmarshalText(x) did not fail")
+    }
+}
+
+func TestConvertToRate(t *testing.T) {
+    tests := []struct {
+        name     string
+        amount   int64
+        from, to string
+        rate     string
+        want     int64
+    }{
+        {"exact decimal", 10000, "EUR", "USD", "1.0837", 10837},
+        {"rounds half up", 5, "EUR", "USD", "0.1", 1},
+        {"float64 trap", 1000000000, "EUR", "USD", "1.005", 1005000000},
+        {"to zero decimals", 10000, "USD", "JPY", "151.37", 15137},
+        {"from zero decimals", 15137, "JPY", "USD", "100/15137", 10000},
+        {"to three decimals", 10000, "EUR", "KWD", "0.3341", 33410},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := mustNew(t, tt.amount, tt.from).ConvertToRate(tt.to, MustParseRate(tt.rate))
+            if err != nil {
+                t.Fatal(err)
+            }
+            if got.Amount() != tt.want {
+                t.Errorf("ConvertToRate() = %d, want %d", got.Amount(), tt.want)
+            }
+        })
+    }
+}
+
+func TestConvertToRateOverflow(t *testing.T) {
+    if _, err := mustNew(t, math.MaxInt64/2, "USD").ConvertToRate("EUR", MustParseRate("3")); err == nil {
+        t.Error("ConvertToRate() did not report overflow")
+    }
+}
+
+func TestRateAdapters(t *testing.T) {
+    converter := mapConverter{{From: "EUR", To: "USD"}: 1.0837}
+    provider := ProviderFromConverter(converter)
+    rate, err := provider.GetExactRate("EUR", "USD", nil)
+    if err != nil || rate.String() != "1.0837" {
+        t.Errorf("GetExactRate() through the adapter = %v, %v, want 1.0837", rate, err)
+    }
+    if _, err := provider.GetExactRate("USD", "EUR", nil); err == nil {
+        t.Error("adapter did not pass through a missing rate")
+    }
+
+    back := ConverterFromProvider(provider)
+    if f, err := back.GetRate("EUR", "USD", nil); err != nil || f != 1.0837 {
+        t.Errorf("GetRate() through both adapters = %v, %v, want 1.0837", f, err)
+    }
+
+    table := NewRateTable()
+    if ProviderFromConverter(table) != RateProvider(table) || ConverterFromProvider(table) != CurrencyConverter(table) {
+        t.Error("adapters wrapped a value that already implements both interfaces")
+    }
+}
+
+func TestConvertViaReferenceExact(t *testing.T) {
+    savedProvider, savedConverter := DefaultRateProvider, DefaultConverter
+    defer func() { DefaultRateProvider, DefaultConverter = savedProvider, savedConverter }()
+
+    table := NewRateTable()
+    for _, r := range []struct {
+        from, to, rate string
+    }{
+        {"BRL", "USD", "0.2"},
+        {"USD", "JPY", "150.5"},
+    } {
+        if err := table.SetRate(r.from, r.to, MustParseRate(r.rate), day(2024, 1, 1)); err != nil {
+            t.Fatal(err)
+        }
+    }
+    DefaultRateProvider, DefaultConverter = table, nil
+
+    got, err := mustNew(t, 333, "BRL").ConvertViaReferenceExact("JPY", "USD", nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    // 3.33 BRL × 0.2 × 150.5 = 100.233 JPY, rounded once; rounding the 0.666 USD leg
+    // first would give 0.67 × 150.5 = 100.835, i.e. 101 JPY
+    if got.Amount() != 100 {
+        t.Errorf("ConvertViaReferenceExact() = %d, want 100", got.Amount())
+    }
+
+    DefaultRateProvider = nil
+    if _, err := mustNew(t, 333, "BRL").ConvertViaReferenceExact("JPY", "USD", nil); err == nil {
+        t.Error("ConvertViaReferenceExact() without a provider did not fail")
+    }
+}
@@ -0,0 +1,135 @@
+package money
+
+import (
+    "context"
+    "fmt"
+    "time"
+)
+
+// RateProviderContext supplies exact exchange rates with cancellation support and
+// batch lookups, for providers backed by slow or remote sources
+type RateProviderContext interface {
+    // GetRate returns the rate for one pair
+    GetRate(ctx context.Context, from, to string, date *time.Time) (Rate, error)
+    // GetRates returns a rate for every requested pair, or an error
+    GetRates(ctx context.Context, pairs []CurrencyPair, date *time.Time) (map[CurrencyPair]Rate, error)
+}
+
+// contextRateProvider adapts a RateProvider to RateProviderContext, checking for
+// cancellation between lookups
+type contextRateProvider struct {
+    provider RateProvider
+}
+
+// ContextProviderFromProvider adapts a RateProvider into a RateProviderContext.
+// Batch lookups are performed one pair at a time, stopping when ctx is done.
+func ContextProviderFromProvider(provider RateProvider) RateProviderContext {
+    return &contextRateProvider{provider: provider}
+}
+
+// ContextProviderFromConverter adapts a float64 CurrencyConverter into a RateProviderContext
+func ContextProviderFromConverter(converter CurrencyConverter) RateProviderContext {
+    return ContextProviderFromProvider(ProviderFromConverter(converter))
+}
+
+func (p *contextRateProvider) GetRate(ctx context.Context, from, to string, date *time.Time) (Rate, error) {
+    if err := ctx.Err(); err != nil {
+        return Rate{}, err
+    }
+    return p.provider.GetExactRate(from, to, date)
+}
+
+func (p *contextRateProvider) GetRates(ctx context.Context, pairs []CurrencyPair, date *time.Time) (map[CurrencyPair]Rate, error) {
+    rates := make(map[CurrencyPair]Rate, len(pairs))
+    for _, pair := range pairs {
+        if _, done := rates[pair]; done {
+            continue
+        }
+        rate, err := p.GetRate(ctx, pair.From, pair.To, date)
+        if err != nil {
+            return nil, err
+        }
+        rates[pair] = rate
+    }
+    return rates, nil
+}
+
+// ProviderName implements NamedProvider using the adapted provider's name
+func (p *contextRateProvider) ProviderName() string {
+    return providerName(p.provider)
+}
+
+// contextProviderOrDefault returns provider, or an adapter over the default
+// rate provider when provider is nil
+func contextProviderOrDefault(provider RateProviderContext) (RateProviderContext, error) {
+    if provider != nil {
+        return provider, nil
+    }
+    if fallback := defaultRateProvider(); fallback != nil {
+        return ContextProviderFromProvider(fallback), nil
+    }
+    return nil, &ValidationError{
+        Field:   "converter",
+        Message: "no rate provider or currency converter configured",
+    }
+}
+
+// ConvertCtx converts Money to another currency with an exact rate from provider
+// (DefaultRateProvider or DefaultConverter when nil), honouring ctx cancellation
+func (m *Money) ConvertCtx(ctx context.Context, provider RateProviderContext, targetCurrency string, date *time.Time) (*Money, error) {
+    provider, err := contextProviderOrDefault(provider)
+    if err != nil {
+        return nil, err
+    }
+
+    rate, err := provider.GetRate(ctx, m.currency.code(), targetCurrency, date)
+    if err != nil {
+        return nil, &ValidationError{
+            Field:   "exchange_rate",
+            Message: fmt.Sprintf("could not fetch rate from %s to %s: %v", m.currency.code(), targetCurrency, err),
+            Err:     err,
+        }
+    }
+    return m.ConvertToRate(targetCurrency, rate)
+}
+
+// ConvertSliceCtx converts every Money value in a slice to the target currency.
+// Rates are fetched with a single GetRates call covering each distinct source currency,
+// so large reports need one batch lookup rather than one lookup per element.
+func ConvertSliceCtx(ctx context.Context, provider RateProviderContext, slice MoneySlice, targetCurrency string, date *time.Time) (MoneySlice, error) {
+    provider, err := contextProviderOrDefault(provider)
+    if err != nil {
+        return nil, err
+    }
+
+    seen := make(map[currencyHandle]bool)
+    var pairs []CurrencyPair
+    for _, money := range slice {
+        if !seen[money.currency] {
+            seen[money.currency] = true
+            pairs = append(pairs, CurrencyPair{From: money.currency.code(), To: targetCurrency})
+        }
+    }
+
+    rates, err := provider.GetRates(ctx, pairs, date)
+    if err != nil {
+        return nil, &ValidationError{
+            Field:   "exchange_rate",
+            Message: fmt.Sprintf("could not fetch rates to %s: %v", targetCurrency, err),
+            Err:     err,
+        }
+    }
+
+    result := make(MoneySlice, len(slice))
+    for i, money := range slice {
+        pair := CurrencyPair{From: money.currency.code(), To: targetCurrency}
+        rate, ok := rates[pair]
+        if !ok {
+            return nil, &RateNotFoundError{From: pair.From, To: pair.To, Date: date}
+        }
+        if result[i], err = money.ConvertToRate(targetCurrency, rate); err != nil {
+            return nil, err
+        }
+    }
+    return result, nil
+}
@@ -0,0 +1,142 @@
+package money
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+)
+
+// batchProvider is a RateProviderContext that records its batch lookups and can
+// omit pairs from its answers
+type batchProvider struct {
+    RateProviderContext
+    batches [][]CurrencyPair
+    omit    CurrencyPair
+}
+
+func (p *batchProvider) GetRates(ctx context.Context, pairs []CurrencyPair, date *time.Time) (map[CurrencyPair]Rate, error) {
+    p.batches = append(p.batches, pairs)
+    rates, err := p.RateProviderContext.GetRates(ctx, pairs, date)
+    delete(rates, p.omit)
+    return rates, err
+}
+
+func contextTable(t *testing.T) RateProviderContext {
+    t.Helper()
+    table := NewRateTable()
+    for _, r := range []struct {
+        from, to, rate string
+    }{
+        {"EUR", "USD", "1.1"},
+        {"GBP", "USD", "1.25"},
+    } {
+        if err := table.SetRate(r.from, r.to, MustParseRate(r.rate), day(2024, 1, 1)); err != nil {
+            t.Fatal(err)
+        }
+    }
+    return ContextProviderFromProvider(table)
+}
+
+func TestConvertCtx(t *testing.T) {
+    cancelled, cancel := context.WithCancel(context.Background())
+    cancel()
+
+    tests := []struct {
+        name    string
+        ctx     context.Context
+        target  string
+        want    int64
+        wantErr error // Matched with errors.Is when set
+    }{
+        {"converts", context.Background(), "USD", 1100, nil},
+        {"inverse", context.Background(), "EUR", 1000, nil},
+        {"cancelled", cancelled, "USD", 0, context.Canceled},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            source := mustNew(t, 1000, "EUR")
+            if tt.target == "EUR" {
+                source = mustNew(t, 1100, "USD")
+            }
+            got, err := source.ConvertCtx(tt.ctx, contextTable(t), tt.target, nil)
+            if tt.wantErr != nil {
+                var validation *ValidationError
+                if !errors.Is(err, tt.wantErr) || !errors.As(err, &validation) || validation.Field != "exchange_rate" {
+                    t.Errorf("ConvertCtx() error = %v, want exchange_rate ValidationError wrapping %v", err, tt.wantErr)
+                }
+                return
+            }
+            if err != nil {
+                t.Fatal(err)
+            }
+            if got.Amount() != tt.want {
+                t.Errorf("ConvertCtx() = %d, want %d", got.Amount(), tt.want)
+            }
+        })
+    }
+}
+
+func TestConvertCtxMissingRate(t *testing.T) {
+    _, err := mustNew(t, 1000, "EUR").ConvertCtx(context.Background(), contextTable(t), "JPY", nil)
+    var notFound *RateNotFoundError
+    if !errors.As(err, &notFound) {
+        t.Errorf("ConvertCtx() error = %v, want it to wrap RateNotFoundError", err)
+    }
+}
+
+func TestConvertSliceCtx(t *testing.T) {
+    slice := MoneySlice{mustNew(t, 1000, "EUR"), mustNew(t, 2000, "GBP"), mustNew(t, 500, "EUR"), mustNew(t, 300, "USD")}
+
+    tests := []struct {
+        name    string
+        omit    CurrencyPair
+        want    []int64
+        wantErr bool
+    }{
+        {"converts", CurrencyPair{}, []int64{1100, 2500, 550, 300}, false},
+        {"missing pair in batch", CurrencyPair{From: "GBP", To: "USD"}, nil, true},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            provider := &batchProvider{RateProviderContext: contextTable(t), omit: tt.omit}
+            got, err := ConvertSliceCtx(context.Background(), provider, slice, "USD", nil)
+            if len(provider.batches) != 1 || len(provider.batches[0]) != 3 {
+                t.Errorf("batches = %v, want one batch of three distinct pairs", provider.batches)
+            }
+            if (err != nil) != tt.wantErr {
+                t.Fatalf("ConvertSliceCtx() error = %v, wantErr %v", err, tt.wantErr)
+            }
+            for i, want := range tt.want {
+                if got[i].Amount() != want || got[i].Currency().Code != "USD" {
+                    t.Errorf("result[%d] = %v, want %d USD", i, got[i], want)
+                }
+            }
+        })
+    }
+}
+
+func TestConvertSliceCtxCancelled(t *testing.T) {
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+    _, err := ConvertSliceCtx(ctx, contextTable(t), MoneySlice{mustNew(t, 1000, "EUR")}, "USD", nil)
+    if !errors.Is(err, context.Canceled) {
+        t.Errorf("ConvertSliceCtx() error = %v, want context.Canceled", err)
+    }
+}
+
+func TestContextProviderDefaults(t *testing.T) {
+    savedProvider, savedConverter := DefaultRateProvider, DefaultConverter
+    defer func() { DefaultRateProvider, DefaultConverter = savedProvider, savedConverter }()
+
+    DefaultRateProvider, DefaultConverter = nil, mapConverter{{From: "EUR", To: "USD"}: 1.1}
+    if got, err := mustNew(t, 1000, "EUR").ConvertCtx(context.Background(), nil, "USD", nil); err != nil || got.Amount() != 1100 {
+        t.Errorf("ConvertCtx() with DefaultConverter = %v, %v", got, err)
+    }
+
+    DefaultConverter = nil
+    var validation *ValidationError
+    if _, err := mustNew(t, 1000, "EUR").ConvertCtx(context.Background(), nil, "USD", nil); !errors.As(err, &validation) || validation.Field != "converter" {
+        t.Errorf("ConvertCtx() without defaults error = %v, want converter ValidationError", err)
+    }
+}
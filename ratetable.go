@@ -0,0 +1,328 @@
+package money
+
+import (
+    "fmt"
+    "math/big"
+    "sort"
+    "sync"
+    "time"
+)
+
+// CurrencyPair identifies a conversion direction between two currencies
+type CurrencyPair struct {
+    From string
+    To   string
+}
+
+// String returns the pair in "FROM/TO" form
+func (p CurrencyPair) String() string {
+    return p.From + "/" + p.To
+}
+
+// rateEntry is a rate that applies from its effective date onwards
+type rateEntry struct {
+    effective time.Time
+    rate      Rate
+}
+
+// DateResolution selects how a RateTable answers lookups for dates without a stored rate
+type DateResolution int
+
+const (
+    ResolvePrevious    DateResolution = iota // Use the latest rate effective on or before the date
+    ResolveExact                             // Only use a rate effective on the same calendar day
+    ResolveNearest                           // Use the closest rate before or after the date, preferring the earlier on ties
+    ResolveInterpolate                       // Interpolate linearly between the surrounding rates, both within the lookback limits
+)
+
+// RateTable is an in-memory CurrencyConverter and RateProvider backed by rates set by the application.
+// Lookups resolve the requested date according to Resolution, by default using the most
+// recent rate effective on or before it, fall back to the inverse of the opposite direction
+// when only that one is known, and return 1
+// for same-currency lookups. When Base is set, pairs that are not stored in either
+// direction are crossed through the base currency, as for rates quoted against EUR.
+// The zero value is an empty table ready to use. A RateTable is safe for concurrent use.
+type RateTable struct {
+    Name string // Reported as the provider in conversion audit records
+    Base string // Currency used for cross rates; set before the table is shared
+
+    // Resolution and MaxLookback control how dates without a stored rate are answered.
+    // MaxLookback limits how far from the requested date a fallback rate may be;
+    // zero means unlimited. A nil date always selects the latest rate.
+    Resolution  DateResolution
+    MaxLookback time.Duration
+
+    // Calendar, when set, makes date fallback follow business days: MaxLookbackDays
+    // limits fallbacks to that many business days, and ResolveExact answers a date
+    // that is not a business day with the rate of the previous business day
+    Calendar        *Calendar
+    MaxLookbackDays int
+
+    mu    sync.RWMutex
+    rates map[CurrencyPair][]rateEntry // Sorted by effective date
+}
+
+// NewRateTable creates an empty RateTable
+func NewRateTable() *RateTable {
+    return &RateTable{rates: make(map[CurrencyPair][]rateEntry)}
+}
+
+// Set records the rate from one currency to another, effective from the given date.
+// A rate set again for the same pair and date replaces the previous one.
+// The float64 rate is stored at its shortest decimal representation.
+func (t *RateTable) Set(from, to string, rate float64, effectiveDate time.Time) error {
+    exact, err := RateFromFloat(rate)
+    if err != nil {
+        return err
+    }
+    return t.SetRate(from, to, exact, effectiveDate)
+}
+
+// SetRate records an exact rate from one currency to another, effective from the given date
+func (t *RateTable) SetRate(from, to string, rate Rate, effectiveDate time.Time) error {
+    if _, err := GetCurrency(from); err != nil {
+        return err
+    }
+    if _, err := GetCurrency(to); err != nil {
+        return err
+    }
+    if !rate.IsPositive() {
+        return &ValidationError{
+            Field:   "rate",
+            Message: fmt.Sprintf("rate from %s to %s must be positive", from, to),
+        }
+    }
+
+    pair := CurrencyPair{From: from, To: to}
+    t.mu.Lock()
+    defer t.mu.Unlock()
+
+    if t.rates == nil {
+        t.rates = make(map[CurrencyPair][]rateEntry)
+    }
+    entries := t.rates[pair]
+    i := sort.Search(len(entries), func(i int) bool {
+        return !entries[i].effective.Before(effectiveDate)
+    })
+    if i < len(entries) && entries[i].effective.Equal(effectiveDate) {
+        entries[i].rate = rate
+        return nil
+    }
+    entries = append(entries, rateEntry{})
+    copy(entries[i+1:], entries[i:])
+    entries[i] = rateEntry{effective: effectiveDate, rate: rate}
+    t.rates[pair] = entries
+    return nil
+}
+
+// GetRate implements CurrencyConverter. A nil date selects the latest known rate.
+func (t *RateTable) GetRate(from, to string, date *time.Time) (float64, error) {
+    rate, err := t.GetExactRate(from, to, date)
+    if err != nil {
+        return 0, err
+    }
+    return rate.Float64(), nil
+}
+
+// GetExactRate implements RateProvider. A nil date selects the latest known rate.
+func (t *RateTable) GetExactRate(from, to string, date *time.Time) (Rate, error) {
+    info, err := t.GetRateInfo(from, to, date)
+    if err != nil {
+        return Rate{}, err
+    }
+    return info.Rate, nil
+}
+
+// GetRateInfo implements RateInfoProvider, reporting the effective date of the
+// stored rate that answered the lookup
+func (t *RateTable) GetRateInfo(from, to string, date *time.Time) (RateInfo, error) {
+    info := RateInfo{From: from, To: to, Provider: t.ProviderName()}
+    if from == to {
+        if _, err := GetCurrency(from); err != nil {
+            return RateInfo{}, err
+        }
+        info.Rate = Rate{r: big.NewRat(1, 1)}
+        if date != nil {
+            info.EffectiveDate = *date
+        }
+        return info, nil
+    }
+
+    t.mu.RLock()
+    defer t.mu.RUnlock()
+
+    if entry, ok := t.directOrInverse(from, to, date); ok {
+        info.Rate, info.EffectiveDate = entry.rate, entry.effective
+        return info, nil
+    }
+    if t.Base != "" && from != t.Base && to != t.Base {
+        first, ok1 := t.directOrInverse(from, t.Base, date)
+        second, ok2 := t.directOrInverse(t.Base, to, date)
+        if ok1 && ok2 {
+            info.Rate = first.rate.Mul(second.rate)
+            info.EffectiveDate = first.effective
+            if second.effective.Before(first.effective) {
+                info.EffectiveDate = second.effective
+            }
+            return info, nil
+        }
+    }
+    return RateInfo{}, &RateNotFoundError{From: from, To: to, Date: date}
+}
+
+// ListRates implements RateLister, returning the rate of every stored pair that
+// answers date (or the latest when date is nil), ordered by pair
+func (t *RateTable) ListRates(date *time.Time) []RateInfo {
+    t.mu.RLock()
+    defer t.mu.RUnlock()
+
+    result := make([]RateInfo, 0, len(t.rates))
+    for pair := range t.rates {
+        if entry, ok := t.lookup(pair, date, false); ok {
+            result = append(result, RateInfo{
+                From:          pair.From,
+                To:            pair.To,
+                Rate:          entry.rate,
+                Provider:      t.ProviderName(),
+                EffectiveDate: entry.effective,
+            })
+        }
+    }
+    sort.Slice(result, func(i, j int) bool {
+        if result[i].From != result[j].From {
+            return result[i].From < result[j].From
+        }
+        return result[i].To < result[j].To
+    })
+    return result
+}
+
+// ProviderName implements NamedProvider, returning Name or "RateTable" when unset
+func (t *RateTable) ProviderName() string {
+    if t.Name != "" {
+        return t.Name
+    }
+    return "RateTable"
+}
+
+// directOrInverse finds the rate for a pair stored in either direction.
+// The caller must hold t.mu.
+func (t *RateTable) directOrInverse(from, to string, date *time.Time) (rateEntry, bool) {
+    if entry, ok := t.lookup(CurrencyPair{From: from, To: to}, date, false); ok {
+        return entry, true
+    }
+    return t.lookup(CurrencyPair{From: to, To: from}, date, true)
+}
+
+// Range returns the stored rates for a pair effective between start and end inclusive,
+// in date order. When only the opposite direction is stored its inverted rates are returned.
+func (t *RateTable) Range(from, to string, start, end time.Time) []RateInfo {
+    t.mu.RLock()
+    defer t.mu.RUnlock()
+
+    pair, inverse := CurrencyPair{From: from, To: to}, false
+    if len(t.rates[pair]) == 0 {
+        pair, inverse = CurrencyPair{From: to, To: from}, true
+    }
+    entries := t.rates[pair]
+    first := sort.Search(len(entries), func(i int) bool {
+        return !entries[i].effective.Before(start)
+    })
+    last := sort.Search(len(entries), func(i int) bool {
+        return entries[i].effective.After(end)
+    })
+
+    var result []RateInfo
+    for _, entry := range entries[first:max(first, last)] {
+        info := RateInfo{From: from, To: to, Rate: entry.rate, Provider: t.ProviderName(), EffectiveDate: entry.effective}
+        if inverse {
+            info.Rate = entry.rate.Inverse()
+        }
+        result = append(result, info)
+    }
+    return result
+}
+
+// lookup finds the entry for a pair that answers date under the table's Resolution,
+// inverting its rate when invert is set. Interpolated entries are effective on the
+// requested date and are interpolated in the stored direction before inverting, so the
+// rates returned for the two directions of a pair are always exact inverses. The caller must hold t.mu.
+func (t *RateTable) lookup(pair CurrencyPair, date *time.Time, invert bool) (rateEntry, bool) {
+    entries := t.rates[pair]
+    if len(entries) == 0 {
+        return rateEntry{}, false
+    }
+    found := func(entry rateEntry) (rateEntry, bool) {
+        if invert {
+            entry.rate = entry.rate.Inverse()
+        }
+        return entry, true
+    }
+    if date == nil {
+        return found(entries[len(entries)-1])
+    }
+
+    // entries[:next] are effective on or before date
+    next := sort.Search(len(entries), func(i int) bool {
+        return entries[i].effective.After(*date)
+    })
+    hasPrevious := next > 0 && t.withinLookback(entries[next-1].effective, *date)
+    hasNext := next < len(entries) && t.withinLookback(*date, entries[next].effective)
+
+    switch t.Resolution {
+    case ResolveExact:
+        day := truncateDay(*date)
+        if t.Calendar != nil && !t.Calendar.IsBusinessDay(day) {
+            day = t.Calendar.PreviousBusinessDay(day)
+        }
+        end := day.AddDate(0, 0, 1)
+        last := sort.Search(len(entries), func(i int) bool {
+            return !entries[i].effective.Before(end)
+        })
+        if last > 0 && sameDay(entries[last-1].effective, day) {
+            return found(entries[last-1])
+        }
+        return rateEntry{}, false
+    case ResolveNearest:
+        if hasNext && (!hasPrevious || entries[next].effective.Sub(*date) < date.Sub(entries[next-1].effective)) {
+            return found(entries[next])
+        }
+    case ResolveInterpolate:
+        if hasPrevious && hasNext && !entries[next-1].effective.Equal(*date) {
+            return found(interpolate(entries[next-1], entries[next], *date))
+        }
+    }
+
+    if hasPrevious {
+        return found(entries[next-1])
+    }
+    return rateEntry{}, false
+}
+
+// withinLookback reports whether two dates are no further apart than MaxLookback,
+// and no more than MaxLookbackDays business days apart when a Calendar is set
+func (t *RateTable) withinLookback(earlier, later time.Time) bool {
+    if t.MaxLookback > 0 && later.Sub(earlier) > t.MaxLookback {
+        return false
+    }
+    if t.Calendar != nil && t.MaxLookbackDays > 0 {
+        return t.Calendar.businessDaysWithin(earlier, later, t.MaxLookbackDays)
+    }
+    return true
+}
+
+// sameDay reports whether an effective date falls on the same calendar day as date,
+// in date's location
+func sameDay(effective, date time.Time) bool {
+    y1, m1, d1 := effective.In(date.Location()).Date()
+    y2, m2, d2 := date.Date()
+    return y1 == y2 && m1 == m2 && d1 == d2
+}
+
+// interpolate returns the exact linear interpolation between two entries at date
+func interpolate(before, after rateEntry, date time.Time) rateEntry {
+    fraction := big.NewRat(int64(date.Sub(before.effective)), int64(after.effective.Sub(before.effective)))
+    delta := new(big.Rat).Sub(after.rate.rat(), before.rate.rat())
+    delta.Mul(delta, fraction)
+    return rateEntry{effective: date, rate: Rate{r: delta.Add(delta, before.rate.rat())}}
+}
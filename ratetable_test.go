@@ -0,0 +1,286 @@
+package money
+
+import (
+    "errors"
+    "testing"
+    "time"
+)
+
+// day returns midnight UTC on the given date
+func day(year int, month time.Month, d int) time.Time {
+    return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestRateTableGetRate(t *testing.T) {
+    table := NewRateTable()
+    table.Base = "EUR"
+    for _, r := range []struct {
+        from, to string
+        rate     string
+        date     time.Time
+    }{
+        {"EUR", "USD", "1.10", day(2024, 1, 1)},
+        {"EUR", "USD", "1.20", day(2024, 2, 1)},
+        {"EUR", "GBP", "0.85", day(2024, 1, 1)},
+        {"JPY", "EUR", "0.0062", day(2024, 1, 1)},
+    } {
+        if err := table.SetRate(r.from, r.to, MustParseRate(r.rate), r.date); err != nil {
+            t.Fatal(err)
+        }
+    }
+
+    jan15, feb15, dec31 := day(2024, 1, 15), day(2024, 2, 15), day(2023, 12, 31)
+    tests := []struct {
+        name     string
+        from, to string
+        date     *time.Time
+        want     string
+        wantErr  bool
+    }{
+        {"latest for nil date", "EUR", "USD", nil, "1.2", false},
+        {"previous rate", "EUR", "USD", &jan15, "1.1", false},
+        {"later rate", "EUR", "USD", &feb15, "1.2", false},
+        {"effective date itself", "EUR", "USD", &[]time.Time{day(2024, 2, 1)}[0], "1.2", false},
+        {"before first rate", "EUR", "USD", &dec31, "", true},
+        {"inverse", "USD", "EUR", &jan15, "10/11", false},
+        {"cross through base", "USD", "GBP", &jan15, "17/22", false},
+        {"cross through base with inverse legs", "JPY", "USD", &jan15, "0.00682", false},
+        {"same currency", "CHF", "CHF", nil, "1", false},
+        {"unknown pair", "CHF", "SEK", nil, "", true},
+        {"unknown currency", "XXX", "XXX", nil, "", true},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := table.GetExactRate(tt.from, tt.to, tt.date)
+            if (err != nil) != tt.wantErr {
+                t.Fatalf("GetExactRate(%s, %s) error = %v, wantErr %v", tt.from, tt.to, err, tt.wantErr)
+            }
+            if err == nil && got.Cmp(MustParseRate(tt.want)) != 0 {
+                t.Errorf("GetExactRate(%s, %s) = %v, want %s", tt.from, tt.to, got, tt.want)
+            }
+        })
+    }
+}
+
+func TestRateTableNotFound(t *testing.T) {
+    table := NewRateTable()
+    _, err := table.GetRate("EUR", "USD", nil)
+    var notFound *RateNotFoundError
+    if !errors.As(err, &notFound) || notFound.From != "EUR" || notFound.To != "USD" {
+        t.Errorf("GetRate() on an empty table error = %v, want RateNotFoundError for EUR/USD", err)
+    }
+}
+
+func TestRateTableSetRejects(t *testing.T) {
+    tests := []struct {
+        name     string
+        from, to string
+        rate     float64
+    }{
+        {"unknown source", "XXX", "USD", 1},
+        {"unknown target", "EUR", "XXX", 1},
+        {"zero rate", "EUR", "USD", 0},
+        {"negative rate", "EUR", "USD", -1.1},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if err := NewRateTable().Set(tt.from, tt.to, tt.rate, day(2024, 1, 1)); err == nil {
+                t.Errorf("Set(%s, %s, %v) did not fail", tt.from, tt.to, tt.rate)
+            }
+        })
+    }
+}
+
+func TestRateTableReplacesRate(t *testing.T) {
+    table := NewRateTable()
+    for _, rate := range []float64{1.1, 1.15} {
+        if err := table.Set("EUR", "USD", rate, day(2024, 1, 1)); err != nil {
+            t.Fatal(err)
+        }
+    }
+    if rates := table.ListRates(nil); len(rates) != 1 || rates[0].Rate.Float64() != 1.15 {
+        t.Errorf("ListRates() after replacing a rate = %v", rates)
+    }
+}
+
+func TestRateTableZeroValue(t *testing.T) {
+    var table RateTable
+    if _, err := table.GetRate("EUR", "USD", nil); err == nil {
+        t.Error("GetRate() on a zero-value table did not fail")
+    }
+    if rates := table.ListRates(nil); len(rates) != 0 {
+        t.Errorf("ListRates() on a zero-value table = %v", rates)
+    }
+    if err := table.Set("EUR", "USD", 1.1, day(2024, 1, 1)); err != nil {
+        t.Fatal(err)
+    }
+    if rate, err := table.GetRate("USD", "EUR", nil); err != nil || rate != 1/1.1 {
+        t.Errorf("GetRate() on a zero-value table = %v, %v", rate, err)
+    }
+    if name := table.ProviderName(); name != "RateTable" {
+        t.Errorf("ProviderName() = %q, want RateTable", name)
+    }
+}
+
+func TestRateTableListRates(t *testing.T) {
+    table := &RateTable{Name: "test"}
+    for _, r := range []struct {
+        from, to string
+        date     time.Time
+    }{
+        {"USD", "JPY", day(2024, 1, 1)},
+        {"EUR", "USD", day(2024, 1, 1)},
+        {"EUR", "GBP", day(2024, 3, 1)},
+    } {
+        if err := table.Set(r.from, r.to, 2, r.date); err != nil {
+            t.Fatal(err)
+        }
+    }
+
+    feb := day(2024, 2, 1)
+    rates := table.ListRates(&feb)
+    want := []string{"EUR/USD", "USD/JPY"}
+    if len(rates) != len(want) {
+        t.Fatalf("ListRates() = %v, want pairs %v", rates, want)
+    }
+    for i, info := range rates {
+        if pair := (CurrencyPair{From: info.From, To: info.To}).String(); pair != want[i] || info.Provider != "test" {
+            t.Errorf("ListRates()[%d] = %s from %s, want %s from test", i, pair, info.Provider, want[i])
+        }
+    }
+}
+
+func TestRateTableResolution(t *testing.T) {
+    at := func(d time.Time) *time.Time { return &d }
+    noon := day(2024, 1, 1).Add(12 * time.Hour)
+    tests := []struct {
+        name        string
+        resolution  DateResolution
+        maxLookback time.Duration
+        date        time.Time
+        want        string // Empty when no rate should be found
+        effective   time.Time
+    }{
+        {"previous between rates", ResolvePrevious, 0, day(2024, 1, 6), "1.10", day(2024, 1, 1)},
+        {"previous after last rate", ResolvePrevious, 0, day(2024, 3, 1), "1.20", day(2024, 1, 11)},
+        {"previous before first rate", ResolvePrevious, 0, day(2023, 12, 31), "", time.Time{}},
+        {"previous within lookback", ResolvePrevious, 72 * time.Hour, day(2024, 1, 3), "1.10", day(2024, 1, 1)},
+        {"previous beyond lookback", ResolvePrevious, 72 * time.Hour, day(2024, 1, 6), "", time.Time{}},
+        {"exact same day", ResolveExact, 0, noon, "1.10", day(2024, 1, 1)},
+        {"exact other day", ResolveExact, 0, day(2024, 1, 6), "", time.Time{}},
+        {"nearest earlier", ResolveNearest, 0, day(2024, 1, 4), "1.10", day(2024, 1, 1)},
+        {"nearest later", ResolveNearest, 0, day(2024, 1, 8), "1.20", day(2024, 1, 11)},
+        {"nearest tie prefers earlier", ResolveNearest, 0, day(2024, 1, 6), "1.10", day(2024, 1, 1)},
+        {"nearest before first rate", ResolveNearest, 0, day(2023, 12, 31), "1.10", day(2024, 1, 1)},
+        {"nearest later beyond lookback", ResolveNearest, 48 * time.Hour, day(2023, 12, 20), "", time.Time{}},
+        {"interpolate midpoint", ResolveInterpolate, 0, day(2024, 1, 6), "1.15", day(2024, 1, 6)},
+        {"interpolate quarter", ResolveInterpolate, 0, day(2024, 1, 3).Add(12 * time.Hour), "1.125", day(2024, 1, 3).Add(12 * time.Hour)},
+        {"interpolate on stored date", ResolveInterpolate, 0, day(2024, 1, 11), "1.20", day(2024, 1, 11)},
+        {"interpolate after last rate", ResolveInterpolate, 0, day(2024, 2, 1), "1.20", day(2024, 1, 11)},
+        {"interpolate later point beyond lookback", ResolveInterpolate, 7 * 24 * time.Hour, day(2024, 1, 3), "1.10", day(2024, 1, 1)},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            table := &RateTable{Resolution: tt.resolution, MaxLookback: tt.maxLookback}
+            for _, r := range []struct {
+                rate string
+                date time.Time
+            }{
+                {"1.10", day(2024, 1, 1)},
+                {"1.20", day(2024, 1, 11)},
+            } {
+                if err := table.SetRate("EUR", "USD", MustParseRate(r.rate), r.date); err != nil {
+                    t.Fatal(err)
+                }
+            }
+
+            info, err := table.GetRateInfo("EUR", "USD", at(tt.date))
+            if tt.want == "" {
+                if err == nil {
+                    t.Errorf("GetRateInfo() = %v, want no rate", info.Rate)
+                }
+                return
+            }
+            if err != nil {
+                t.Fatal(err)
+            }
+            if info.Rate.Cmp(MustParseRate(tt.want)) != 0 || !info.EffectiveDate.Equal(tt.effective) {
+                t.Errorf("GetRateInfo() = %v effective %v, want %s effective %v", info.Rate, info.EffectiveDate, tt.want, tt.effective)
+            }
+        })
+    }
+}
+
+func TestRateTableInterpolatesInverseStoredPairs(t *testing.T) {
+    table := &RateTable{Resolution: ResolveInterpolate}
+    if err := table.SetRate("EUR", "USD", MustParseRate("0.8"), day(2024, 1, 1)); err != nil {
+        t.Fatal(err)
+    }
+    if err := table.SetRate("EUR", "USD", MustParseRate("1.0"), day(2024, 1, 3)); err != nil {
+        t.Fatal(err)
+    }
+
+    tests := []struct {
+        date    time.Time
+        direct  string
+        inverse string
+    }{
+        {day(2024, 1, 2), "0.9", "10/9"},
+        {day(2024, 1, 1).Add(12 * time.Hour), "0.85", "20/17"},
+        {day(2024, 1, 2).Add(18 * time.Hour), "0.975", "40/39"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.date.Format(time.RFC3339), func(t *testing.T) {
+            direct, err := table.GetExactRate("EUR", "USD", &tt.date)
+            if err != nil {
+                t.Fatal(err)
+            }
+            inverse, err := table.GetExactRate("USD", "EUR", &tt.date)
+            if err != nil {
+                t.Fatal(err)
+            }
+            if direct.Cmp(MustParseRate(tt.direct)) != 0 || inverse.Cmp(MustParseRate(tt.inverse)) != 0 {
+                t.Errorf("rates = %v and %v, want %s and %s", direct, inverse, tt.direct, tt.inverse)
+            }
+            if product := direct.Mul(inverse); product.Cmp(MustParseRate("1")) != 0 {
+                t.Errorf("EUR/USD × USD/EUR = %v, want 1", product)
+            }
+        })
+    }
+}
+
+func TestRateTableRange(t *testing.T) {
+    table := NewRateTable()
+    for i, rate := range []string{"1.10", "1.20", "1.25", "1.30"} {
+        if err := table.SetRate("EUR", "USD", MustParseRate(rate), day(2024, 1, 1+7*i)); err != nil {
+            t.Fatal(err)
+        }
+    }
+
+    tests := []struct {
+        name       string
+        from, to   string
+        start, end time.Time
+        want       []string
+    }{
+        {"all", "EUR", "USD", day(2023, 1, 1), day(2025, 1, 1), []string{"1.10", "1.20", "1.25", "1.30"}},
+        {"inclusive bounds", "EUR", "USD", day(2024, 1, 8), day(2024, 1, 15), []string{"1.20", "1.25"}},
+        {"between entries", "EUR", "USD", day(2024, 1, 9), day(2024, 1, 14), nil},
+        {"end before start", "EUR", "USD", day(2024, 1, 15), day(2024, 1, 8), nil},
+        {"inverse", "USD", "EUR", day(2024, 1, 8), day(2024, 1, 8), []string{"5/6"}},
+        {"unknown pair", "EUR", "GBP", day(2023, 1, 1), day(2025, 1, 1), nil},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got := table.Range(tt.from, tt.to, tt.start, tt.end)
+            if len(got) != len(tt.want) {
+                t.Fatalf("Range() = %v, want %v", got, tt.want)
+            }
+            for i, info := range got {
+                if info.Rate.Cmp(MustParseRate(tt.want[i])) != 0 || info.From != tt.from || info.To != tt.to {
+                    t.Errorf("Range()[%d] = %s/%s %v, want %s/%s %s", i, info.From, info.To, info.Rate, tt.from, tt.to, tt.want[i])
+                }
+            }
+        })
+    }
+}
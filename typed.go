@@ -0,0 +1,211 @@
+package money
+
+import "math"
+
+// CurrencyTag identifies a currency at compile time.
+// Tags are empty types; the predefined ones below cover every currency in CurrencyMap,
+// and applications can declare their own for currencies they register.
+type CurrencyTag interface {
+    CurrencyCode() string
+}
+
+// Predefined currency tags
+type USD struct{} // US Dollar
+type EUR struct{} // Euro
+type JPY struct{} // Japanese Yen
+type GBP struct{} // British Pound Sterling
+type CHF struct{} // Swiss Franc
+type BRL struct{} // Brazilian Real
+type ARS struct{} // Argentine Peso
+type UYU struct{} // Uruguayan Peso
+type CLP struct{} // Chilean Peso
+type CAD struct{} // Canadian Dollar
+type MXN struct{} // Mexican Peso
+type CNY struct{} // Chinese Yuan (Renminbi)
+type HKD struct{} // Hong Kong Dollar
+type SGD struct{} // Singapore Dollar
+type INR struct{} // Indian Rupee
+type KRW struct{} // South Korean Won
+type TWD struct{} // New Taiwan Dollar
+type AUD struct{} // Australian Dollar
+type NZD struct{} // New Zealand Dollar
+type SEK struct{} // Swedish Krona
+type NOK struct{} // Norwegian Krone
+type DKK struct{} // Danish Krone
+type HUF struct{} // Hungarian Forint
+type ISK struct{} // Icelandic Króna
+type BHD struct{} // Bahraini Dinar
+type JOD struct{} // Jordanian Dinar
+type KWD struct{} // Kuwaiti Dinar
+type OMR struct{} // Omani Rial
+type TND struct{} // Tunisian Dinar
+
+func (USD) CurrencyCode() string { return "USD" }
+func (EUR) CurrencyCode() string { return "EUR" }
+func (JPY) CurrencyCode() string { return "JPY" }
+func (GBP) CurrencyCode() string { return "GBP" }
+func (CHF) CurrencyCode() string { return "CHF" }
+func (BRL) CurrencyCode() string { return "BRL" }
+func (ARS) CurrencyCode() string { return "ARS" }
+func (UYU) CurrencyCode() string { return "UYU" }
+func (CLP) CurrencyCode() string { return "CLP" }
+func (CAD) CurrencyCode() string { return "CAD" }
+func (MXN) CurrencyCode() string { return "MXN" }
+func (CNY) CurrencyCode() string { return "CNY" }
+func (HKD) CurrencyCode() string { return "HKD" }
+func (SGD) CurrencyCode() string { return "SGD" }
+func (INR) CurrencyCode() string { return "INR" }
+func (KRW) CurrencyCode() string { return "KRW" }
+func (TWD) CurrencyCode() string { return "TWD" }
+func (AUD) CurrencyCode() string { return "AUD" }
+func (NZD) CurrencyCode() string { return "NZD" }
+func (SEK) CurrencyCode() string { return "SEK" }
+func (NOK) CurrencyCode() string { return "NOK" }
+func (DKK) CurrencyCode() string { return "DKK" }
+func (HUF) CurrencyCode() string { return "HUF" }
+func (ISK) CurrencyCode() string { return "ISK" }
+func (BHD) CurrencyCode() string { return "BHD" }
+func (JOD) CurrencyCode() string { return "JOD" }
+func (KWD) CurrencyCode() string { return "KWD" }
+func (OMR) CurrencyCode() string { return "OMR" }
+func (TND) CurrencyCode() string { return "TND" }
+
+// Typed is an amount whose currency is fixed by its type parameter, so mixing
+// currencies is a compile-time error, e.g. Typed[USD] cannot be added to Typed[EUR].
+// The zero value is zero in currency C.
+type Typed[C CurrencyTag] struct {
+    amount int64
+}
+
+// NewTyped creates a Typed amount from an integer amount in the currency's smallest unit
+func NewTyped[C CurrencyTag](amount int64) Typed[C] {
+    return Typed[C]{amount: amount}
+}
+
+// TypedFrom converts dynamic Money into a Typed amount, failing if the currencies differ
+func TypedFrom[C CurrencyTag](m *Money) (Typed[C], error) {
+    var tag C
+    if m.currency.code() != tag.CurrencyCode() {
+        return Typed[C]{}, &CurrencyMismatchError{
+            Currency1: tag.CurrencyCode(),
+            Currency2: m.currency.code(),
+        }
+    }
+    return Typed[C]{amount: m.amount}, nil
+}
+
+// Money converts a Typed amount into dynamic Money
+func (t Typed[C]) Money() *Money {
+    value := t.Value()
+    return &value
+}
+
+// Value converts a Typed amount into a dynamic Money value
+func (t Typed[C]) Value() Money {
+    var tag C
+    return Money{amount: t.amount, currency: internCurrency(tag.CurrencyCode())}
+}
+
+// Amount returns the amount in the currency's smallest unit
+func (t Typed[C]) Amount() int64 {
+    return t.amount
+}
+
+// CurrencyCode returns the ISO code of the type's currency
+func (t Typed[C]) CurrencyCode() string {
+    var tag C
+    return tag.CurrencyCode()
+}
+
+// Add adds two amounts of the same currency, reporting overflow
+func (t Typed[C]) Add(other Typed[C]) (Typed[C], error) {
+    amount, err := addAmounts(t.amount, other.amount)
+    if err != nil {
+        return Typed[C]{}, err
+    }
+    return Typed[C]{amount: amount}, nil
+}
+
+// Subtract subtracts another amount of the same currency, reporting overflow
+func (t Typed[C]) Subtract(other Typed[C]) (Typed[C], error) {
+    if (other.amount < 0 && t.amount > math.MaxInt64+other.amount) ||
+        (other.amount > 0 && t.amount < math.MinInt64+other.amount) {
+        return Typed[C]{}, &OverflowError{
+            Operation: "subtraction",
+            Amount1:   t.amount,
+            Amount2:   other.amount,
+        }
+    }
+    return Typed[C]{amount: t.amount - other.amount}, nil
+}
+
+// Multiply multiplies the amount by a factor and rounds with DefaultRoundingMethod
+func (t Typed[C]) Multiply(factor float64) Typed[C] {
+    return Typed[C]{amount: round(int64(float64(t.amount)*factor*10), DefaultRoundingMethod)}
+}
+
+// Abs returns the absolute value of the amount
+func (t Typed[C]) Abs() Typed[C] {
+    if t.amount < 0 {
+        return Typed[C]{amount: -t.amount}
+    }
+    return t
+}
+
+// Compare returns -1, 0 or 1 as t is less than, equal to or greater than other
+func (t Typed[C]) Compare(other Typed[C]) int {
+    switch {
+    case t.amount < other.amount:
+        return -1
+    case t.amount > other.amount:
+        return 1
+    }
+    return 0
+}
+
+// Equals checks if two amounts are equal
+func (t Typed[C]) Equals(other Typed[C]) bool {
+    return t.amount == other.amount
+}
+
+// GreaterThan checks if this amount is greater than another
+func (t Typed[C]) GreaterThan(other Typed[C]) bool {
+    return t.amount > other.amount
+}
+
+// LessThan checks if this amount is less than another
+func (t Typed[C]) LessThan(other Typed[C]) bool {
+    return t.amount < other.amount
+}
+
+// IsZero returns true if the amount is zero
+func (t Typed[C]) IsZero() bool {
+    return t.amount == 0
+}
+
+// IsPositive returns true if the amount is greater than zero
+func (t Typed[C]) IsPositive() bool {
+    return t.amount > 0
+}
+
+// IsNegative returns true if the amount is less than zero
+func (t Typed[C]) IsNegative() bool {
+    return t.amount < 0
+}
+
+// Format returns a string representation using default formatting options for the currency
+func (t Typed[C]) Format() string {
+    return t.Money().Format()
+}
+
+// SumTyped returns the sum of amounts of the same currency, reporting overflow
+func SumTyped[C CurrencyTag](values ...Typed[C]) (Typed[C], error) {
+    var total Typed[C]
+    for _, value := range values {
+        var err error
+        if total, err = total.Add(value); err != nil {
+            return Typed[C]{}, err
+        }
+    }
+    return total, nil
+}
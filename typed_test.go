@@ -0,0 +1,149 @@
+package money
+
+import (
+    "errors"
+    "math"
+    "testing"
+)
+
+func TestTypedAdd(t *testing.T) {
+    tests := []struct {
+        name    string
+        a, b    Typed[USD]
+        want    int64
+        wantErr bool
+    }{
+        {"positive", NewTyped[USD](150), NewTyped[USD](250), 400, false},
+        {"negative", NewTyped[USD](150), NewTyped[USD](-250), -100, false},
+        {"zero value", Typed[USD]{}, NewTyped[USD](5), 5, false},
+        {"overflow", NewTyped[USD](math.MaxInt64), NewTyped[USD](1), 0, true},
+        {"underflow", NewTyped[USD](math.MinInt64), NewTyped[USD](-1), 0, true},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := tt.a.Add(tt.b)
+            var overflow *OverflowError
+            if errors.As(err, &overflow) != tt.wantErr {
+                t.Fatalf("Add() error = %v, wantErr %v", err, tt.wantErr)
+            }
+            if err == nil && got.Amount() != tt.want {
+                t.Errorf("Add() = %d, want %d", got.Amount(), tt.want)
+            }
+        })
+    }
+}
+
+func TestTypedSubtract(t *testing.T) {
+    tests := []struct {
+        name    string
+        a, b    Typed[EUR]
+        want    int64
+        wantErr bool
+    }{
+        {"positive", NewTyped[EUR](250), NewTyped[EUR](150), 100, false},
+        {"below zero", NewTyped[EUR](150), NewTyped[EUR](250), -100, false},
+        {"minimum minus zero", NewTyped[EUR](math.MinInt64), NewTyped[EUR](0), math.MinInt64, false},
+        {"overflow", NewTyped[EUR](math.MaxInt64), NewTyped[EUR](-1), 0, true},
+        {"underflow", NewTyped[EUR](math.MinInt64), NewTyped[EUR](1), 0, true},
+        {"zero minus minimum", NewTyped[EUR](0), NewTyped[EUR](math.MinInt64), 0, true},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := tt.a.Subtract(tt.b)
+            var overflow *OverflowError
+            if errors.As(err, &overflow) != tt.wantErr {
+                t.Fatalf("Subtract() error = %v, wantErr %v", err, tt.wantErr)
+            }
+            if err == nil && got.Amount() != tt.want {
+                t.Errorf("Subtract() = %d, want %d", got.Amount(), tt.want)
+            }
+        })
+    }
+}
+
+func TestSumTyped(t *testing.T) {
+    tests := []struct {
+        name    string
+        values  []Typed[JPY]
+        want    int64
+        wantErr bool
+    }{
+        {"empty is zero", nil, 0, false},
+        {"several", []Typed[JPY]{NewTyped[JPY](100), NewTyped[JPY](-30), NewTyped[JPY](5)}, 75, false},
+        {"overflow", []Typed[JPY]{NewTyped[JPY](math.MaxInt64), NewTyped[JPY](1)}, 0, true},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := SumTyped(tt.values...)
+            if (err != nil) != tt.wantErr {
+                t.Fatalf("SumTyped() error = %v, wantErr %v", err, tt.wantErr)
+            }
+            if err == nil && got.Amount() != tt.want {
+                t.Errorf("SumTyped() = %d, want %d", got.Amount(), tt.want)
+            }
+        })
+    }
+}
+
+func TestTypedFrom(t *testing.T) {
+    usd, err := TypedFrom[USD](mustNew(t, 1234, "USD"))
+    if err != nil {
+        t.Fatal(err)
+    }
+    if usd.Amount() != 1234 || usd.CurrencyCode() != "USD" {
+        t.Errorf("TypedFrom[USD]() = %d %s", usd.Amount(), usd.CurrencyCode())
+    }
+    if value := usd.Value(); value != mustValue(t, 1234, "USD") {
+        t.Errorf("Value() = %v, want 1234 USD", value)
+    }
+
+    var mismatch *CurrencyMismatchError
+    if _, err := TypedFrom[EUR](mustNew(t, 1234, "USD")); !errors.As(err, &mismatch) {
+        t.Errorf("TypedFrom[EUR]() of USD error = %v, want CurrencyMismatchError", err)
+    }
+}
+
+func TestTypedZeroValue(t *testing.T) {
+    var zero Typed[KWD]
+    if !zero.IsZero() || zero.CurrencyCode() != "KWD" {
+        t.Errorf("zero Typed[KWD] = %d %s", zero.Amount(), zero.CurrencyCode())
+    }
+    if got := zero.Money().Currency().Precision; got != 3 {
+        t.Errorf("zero Typed[KWD] precision = %d, want 3", got)
+    }
+}
+
+func TestTypedCompare(t *testing.T) {
+    tests := []struct {
+        a, b    Typed[GBP]
+        compare int
+    }{
+        {NewTyped[GBP](100), NewTyped[GBP](200), -1},
+        {NewTyped[GBP](200), NewTyped[GBP](100), 1},
+        {NewTyped[GBP](100), NewTyped[GBP](100), 0},
+    }
+    for _, tt := range tests {
+        if got := tt.a.Compare(tt.b); got != tt.compare {
+            t.Errorf("Compare(%d, %d) = %d, want %d", tt.a.Amount(), tt.b.Amount(), got, tt.compare)
+        }
+        if tt.a.LessThan(tt.b) != (tt.compare < 0) || tt.a.GreaterThan(tt.b) != (tt.compare > 0) || tt.a.Equals(tt.b) != (tt.compare == 0) {
+            t.Errorf("comparisons of %d and %d disagree with Compare", tt.a.Amount(), tt.b.Amount())
+        }
+    }
+}
+
+func TestTypedTagsMatchCurrencyMap(t *testing.T) {
+    tags := []CurrencyTag{
+        USD{}, EUR{}, JPY{}, GBP{}, CHF{}, BRL{}, ARS{}, UYU{}, CLP{}, CAD{}, MXN{},
+        CNY{}, HKD{}, SGD{}, INR{}, KRW{}, TWD{}, AUD{}, NZD{}, SEK{}, NOK{}, DKK{},
+        HUF{}, ISK{}, BHD{}, JOD{}, KWD{}, OMR{}, TND{},
+    }
+    if len(tags) != len(CurrencyMap) {
+        t.Errorf("%d predefined tags for %d currencies in CurrencyMap", len(tags), len(CurrencyMap))
+    }
+    for _, tag := range tags {
+        if _, exists := CurrencyMap[tag.CurrencyCode()]; !exists {
+            t.Errorf("tag %T has no CurrencyMap entry", tag)
+        }
+    }
+}
@@ -25,68 +25,101 @@ type CurrencyConverter interface {
 // DefaultConverter can be set by the application to handle currency conversions
 var DefaultConverter CurrencyConverter
 
+// DefaultRateProvider can be set by the application to supply exact exchange rates.
+// When it is nil, exact conversions fall back to DefaultConverter.
+var DefaultRateProvider RateProvider
+
 // WarnOnFloat64Constructor controls whether a warning appears when using float64 in constructors
 var WarnOnFloat64Constructor = true
 
+// XMLCurrencyAttr is the attribute name that carries the currency code when Money
+// is encoded as an XML element, e.g. <Amt Ccy="EUR">12.34</Amt>
+var XMLCurrencyAttr = "Ccy"
+
 // Currency holds details about each currency
 type Currency struct {
-    Code             string
-    Symbol           string
-    Precision        int
-    SingularName     string
-    PluralName       string
-    GroupSeparator   string
-    DecimalSeparator string
-    SymbolPosition   string // "before" or "after"
+    Code                string
+    Symbol              string
+    Precision           int
+    SingularName        string
+    PluralName          string
+    GroupSeparator      string
+    DecimalSeparator    string
+    SymbolPosition      string // "before" or "after"
+    NarrowSymbol        string // Shortest local form, e.g. "$" for any dollar
+    InternationalSymbol string // Disambiguated form, e.g. "US$" or "CA$"
 }
 
 // CurrencyMap defines available currencies
 // Currency list organized by regions and financial importance
 var CurrencyMap = map[string]Currency{
     // Major World Currencies
-    "USD": {"USD", "$", 2, "Dollar", "Dollars", ",", ".", "before"},      // US Dollar
-    "EUR": {"EUR", "€", 2, "Euro", "Euros", ".", ",", "before"},          // Euro
-    "JPY": {"JPY", "¥", 0, "Yen", "Yen", ",", "", "before"},             // Japanese Yen
-    "GBP": {"GBP", "£", 2, "Pound", "Pounds", ",", ".", "before"},       // British Pound Sterling
-    "CHF": {"CHF", "Fr.", 2, "Franc", "Francs", "'", ".", "before"},     // Swiss Franc
+    "USD": {"USD", "$", 2, "Dollar", "Dollars", ",", ".", "before", "$", "US$"}, // US Dollar
+    "EUR": {"EUR", "€", 2, "Euro", "Euros", ".", ",", "before", "€", "€"}, // Euro
+    "JPY": {"JPY", "¥", 0, "Yen", "Yen", ",", "", "before", "¥", "JP¥"}, // Japanese Yen
+    "GBP": {"GBP", "£", 2, "Pound", "Pounds", ",", ".", "before", "£", "£"}, // British Pound Sterling
+    "CHF": {"CHF", "Fr.", 2, "Franc", "Francs", "'", ".", "before", "Fr.", "CHF"}, // Swiss Franc
 
     // South American Currencies
-    "BRL": {"BRL", "R$", 2, "Real", "Reais", ".", ",", "before"},        // Brazilian Real
-    "ARS": {"ARS", "$", 0, "Peso", "Pesos", ".", ",", "before"},         // Argentine Peso
-    "UYU": {"UYU", "$U", 2, "Peso", "Pesos", ".", ",", "before"},        // Uruguayan Peso
-    "CLP": {"CLP", "$", 0, "Peso", "Pesos", ".", "", "before"},          // Chilean Peso
+    "BRL": {"BRL", "R$", 2, "Real", "Reais", ".", ",", "before", "R$", "R$"}, // Brazilian Real
+    "ARS": {"ARS", "$", 0, "Peso", "Pesos", ".", ",", "before", "$", "AR$"}, // Argentine Peso
+    "UYU": {"UYU", "$U", 2, "Peso", "Pesos", ".", ",", "before", "$", "$U"}, // Uruguayan Peso
+    "CLP": {"CLP", "$", 0, "Peso", "Pesos", ".", "", "before", "$", "CL$"}, // Chilean Peso
     
     // North American Currencies
-    "CAD": {"CAD", "$", 2, "Dollar", "Dollars", ",", ".", "before"},     // Canadian Dollar
-    "MXN": {"MXN", "$", 2, "Peso", "Pesos", ",", ".", "before"},        // Mexican Peso
+    "CAD": {"CAD", "$", 2, "Dollar", "Dollars", ",", ".", "before", "$", "CA$"}, // Canadian Dollar
+    "MXN": {"MXN", "$", 2, "Peso", "Pesos", ",", ".", "before", "$", "MX$"}, // Mexican Peso
 
     // Asia-Pacific Currencies
-    "CNY": {"CNY", "¥", 2, "Yuan", "Yuan", ",", ".", "before"},          // Chinese Yuan (Renminbi)
-    "HKD": {"HKD", "HK$", 2, "Dollar", "Dollars", ",", ".", "before"},   // Hong Kong Dollar
-    "SGD": {"SGD", "$", 2, "Dollar", "Dollars", ",", ".", "before"},     // Singapore Dollar
-    "INR": {"INR", "₹", 2, "Rupee", "Rupees", ",", ".", "before"},      // Indian Rupee
-    "KRW": {"KRW", "₩", 0, "Won", "Won", ",", "", "before"},            // South Korean Won
-    "TWD": {"TWD", "NT$", 2, "Dollar", "Dollars", ",", ".", "before"},   // New Taiwan Dollar
-    "AUD": {"AUD", "$", 2, "Dollar", "Dollars", ",", ".", "before"},     // Australian Dollar
-    "NZD": {"NZD", "$", 2, "Dollar", "Dollars", ",", ".", "before"},     // New Zealand Dollar
+    "CNY": {"CNY", "¥", 2, "Yuan", "Yuan", ",", ".", "before", "¥", "CN¥"}, // Chinese Yuan (Renminbi)
+    "HKD": {"HKD", "HK$", 2, "Dollar", "Dollars", ",", ".", "before", "$", "HK$"}, // Hong Kong Dollar
+    "SGD": {"SGD", "$", 2, "Dollar", "Dollars", ",", ".", "before", "$", "S$"}, // Singapore Dollar
+    "INR": {"INR", "₹", 2, "Rupee", "Rupees", ",", ".", "before", "₹", "₹"}, // Indian Rupee
+    "KRW": {"KRW", "₩", 0, "Won", "Won", ",", "", "before", "₩", "₩"}, // South Korean Won
+    "TWD": {"TWD", "NT$", 2, "Dollar", "Dollars", ",", ".", "before", "$", "NT$"}, // New Taiwan Dollar
+    "AUD": {"AUD", "$", 2, "Dollar", "Dollars", ",", ".", "before", "$", "A$"}, // Australian Dollar
+    "NZD": {"NZD", "$", 2, "Dollar", "Dollars", ",", ".", "before", "$", "NZ$"}, // New Zealand Dollar
     
     // European Currencies (non-EUR)
-    "SEK": {"SEK", "kr", 2, "Krona", "Kronor", " ", ",", "after"},      // Swedish Krona
-    "NOK": {"NOK", "kr", 2, "Krone", "Kroner", " ", ",", "after"},      // Norwegian Krone
-    "DKK": {"DKK", "kr", 2, "Krone", "Kroner", ".", ",", "after"},      // Danish Krone
+    "SEK": {"SEK", "kr", 2, "Krona", "Kronor", " ", ",", "after", "kr", "SEK"}, // Swedish Krona
+    "NOK": {"NOK", "kr", 2, "Krone", "Kroner", " ", ",", "after", "kr", "NOK"}, // Norwegian Krone
+    "DKK": {"DKK", "kr", 2, "Krone", "Kroner", ".", ",", "after", "kr", "DKK"}, // Danish Krone
+    "HUF": {"HUF", "Ft", 2, "Forint", "Forints", " ", ",", "after", "Ft", "HUF"}, // Hungarian Forint
+    "ISK": {"ISK", "kr", 0, "Króna", "Krónur", ".", "", "after", "kr", "ISK"}, // Icelandic Króna
+
+    // Middle Eastern and North African Currencies
+    "BHD": {"BHD", "BD", 3, "Dinar", "Dinars", ",", ".", "before", "BD", "BHD"}, // Bahraini Dinar
+    "JOD": {"JOD", "JD", 3, "Dinar", "Dinars", ",", ".", "before", "JD", "JOD"}, // Jordanian Dinar
+    "KWD": {"KWD", "KD", 3, "Dinar", "Dinars", ",", ".", "before", "KD", "KWD"}, // Kuwaiti Dinar
+    "OMR": {"OMR", "RO", 3, "Rial", "Rials", ",", ".", "before", "RO", "OMR"}, // Omani Rial
+    "TND": {"TND", "DT", 3, "Dinar", "Dinars", ".", ",", "after", "DT", "TND"}, // Tunisian Dinar
 }
 
-// Money represents a monetary value in the smallest unit (e.g., cents)
+// SymbolStyle selects which of a currency's symbols is used when formatting
+type SymbolStyle int
+
+const (
+    SymbolStandard      SymbolStyle = iota // Currency.Symbol, e.g. "$" or "R$"
+    SymbolNarrow                           // Currency.NarrowSymbol
+    SymbolInternational                    // Currency.InternationalSymbol
+    SymbolAuto                             // International form only when the standard symbol is ambiguous
+)
+
+// Money represents a monetary value in the smallest unit (e.g., cents).
+// The currency is held as an interned handle, so values are small and
+// compare by currency code.
 type Money struct {
     amount   int64
-    currency Currency
+    currency currencyHandle
 }
 
 // MoneyFormatOptions defines how a Money instance is formatted
 type MoneyFormatOptions struct {
-    UseSymbol        bool   // Display the currency symbol or code
-    ShowCents        bool   // Show decimal places even if 0
-    SymbolPosition   string // "before" or "after" the amount
-    GroupSeparator   string // Separator for thousands grouping
-    DecimalSeparator string // Separator for decimal places
+    UseSymbol        bool        // Display the currency symbol or code
+    ShowCents        bool        // Show decimal places even if 0
+    SymbolPosition   string      // "before" or "after" the amount
+    GroupSeparator   string      // Separator for thousands grouping
+    DecimalSeparator string      // Separator for decimal places
+    SymbolStyle      SymbolStyle // Which symbol to display when UseSymbol is set
+    HomeCurrency     string      // Reader's local currency code, used by SymbolAuto
 }
@@ -0,0 +1,234 @@
+package money
+
+import (
+    "fmt"
+    "math"
+    "math/big"
+    "sort"
+    "time"
+)
+
+// RateIssueKind classifies a problem found by a RateValidator
+type RateIssueKind int
+
+const (
+    IssueNonPositive RateIssueKind = iota // A rate is zero or negative
+    IssueRoundTrip                        // rate(A,B)*rate(B,A) is not close to 1
+    IssueTriangular                       // rate(A,B)*rate(B,C)*rate(C,A) is not close to 1
+    IssueJump                             // A rate moved too far from its previous value
+    IssueStale                            // The latest rate of a pair is too old
+)
+
+var rateIssueKindNames = map[RateIssueKind]string{
+    IssueNonPositive: "non-positive",
+    IssueRoundTrip:   "round-trip",
+    IssueTriangular:  "triangular",
+    IssueJump:        "jump",
+    IssueStale:       "stale",
+}
+
+// String returns the name of the issue kind
+func (k RateIssueKind) String() string {
+    if name, ok := rateIssueKindNames[k]; ok {
+        return name
+    }
+    return fmt.Sprintf("RateIssueKind(%d)", int(k))
+}
+
+// RateIssue is a single problem found in a set of rates
+type RateIssue struct {
+    Kind      RateIssueKind
+    Pairs     []CurrencyPair // Pairs involved, in cycle order for round trips and triangles
+    Date      time.Time      // Effective date of the rates involved
+    Deviation float64        // Relative deviation from 1 for cycles, relative change for jumps
+    Message   string
+}
+
+// RateReport lists the issues found by a RateValidator
+type RateReport struct {
+    Checked int // Number of rates examined
+    Issues  []RateIssue
+}
+
+// OK reports whether no issues were found
+func (r *RateReport) OK() bool {
+    return len(r.Issues) == 0
+}
+
+// DefaultRateTolerance is the relative deviation allowed for round trips and
+// triangles when RateValidator.Tolerance is zero
+const DefaultRateTolerance = 0.001
+
+// RateValidator checks a set of rates for inconsistencies before they are used.
+// Round trips and triangles are checked among the rates effective on the same day;
+// jumps compare each rate with the previous one for the same pair.
+type RateValidator struct {
+    Tolerance float64       // Relative deviation allowed for cycles; DefaultRateTolerance when zero
+    MaxJump   float64       // Relative change allowed between consecutive rates of a pair; zero disables
+    MaxAge    time.Duration // Age allowed for the latest rate of each pair; zero disables
+    AsOf      time.Time     // Reference time for MaxAge; the current time when zero
+}
+
+// Validate checks rates, such as those returned by ReadECBRates or ReadCSVRates,
+// and returns a report of every issue found
+func (v *RateValidator) Validate(rates []RateInfo) *RateReport {
+    report := &RateReport{Checked: len(rates)}
+    tolerance := v.Tolerance
+    if tolerance <= 0 {
+        tolerance = DefaultRateTolerance
+    }
+
+    // Index valid rates by day and by pair
+    days := make(map[int]map[CurrencyPair]RateInfo)
+    history := make(map[CurrencyPair][]RateInfo)
+    for _, info := range rates {
+        pair := CurrencyPair{From: info.From, To: info.To}
+        if !info.Rate.IsPositive() {
+            report.Issues = append(report.Issues, RateIssue{
+                Kind:    IssueNonPositive,
+                Pairs:   []CurrencyPair{pair},
+                Date:    info.EffectiveDate,
+                Message: fmt.Sprintf("rate %s for %s on %s is not positive", info.Rate, pair, info.EffectiveDate.Format("2006-01-02")),
+            })
+            continue
+        }
+        key := dayKey(info.EffectiveDate)
+        if days[key] == nil {
+            days[key] = make(map[CurrencyPair]RateInfo)
+        }
+        days[key][pair] = info
+        history[pair] = append(history[pair], info)
+    }
+
+    keys := make([]int, 0, len(days))
+    for key := range days {
+        keys = append(keys, key)
+    }
+    sort.Ints(keys)
+    for _, key := range keys {
+        report.Issues = append(report.Issues, v.checkCycles(days[key], tolerance)...)
+    }
+
+    pairs := make([]CurrencyPair, 0, len(history))
+    for pair := range history {
+        pairs = append(pairs, pair)
+    }
+    sort.Slice(pairs, func(i, j int) bool {
+        return pairs[i].String() < pairs[j].String()
+    })
+    asOf := v.AsOf
+    if asOf.IsZero() {
+        asOf = time.Now()
+    }
+    for _, pair := range pairs {
+        series := history[pair]
+        sort.SliceStable(series, func(i, j int) bool {
+            return series[i].EffectiveDate.Before(series[j].EffectiveDate)
+        })
+        if v.MaxJump > 0 {
+            for i := 1; i < len(series); i++ {
+                change := new(big.Rat).Quo(series[i].Rate.rat(), series[i-1].Rate.rat())
+                deviation := relativeDeviation(change)
+                if deviation > v.MaxJump {
+                    report.Issues = append(report.Issues, RateIssue{
+                        Kind:      IssueJump,
+                        Pairs:     []CurrencyPair{pair},
+                        Date:      series[i].EffectiveDate,
+                        Deviation: deviation,
+                        Message: fmt.Sprintf("%s moved from %s to %s (%.2f%%) on %s", pair, series[i-1].Rate, series[i].Rate,
+                            deviation*100, series[i].EffectiveDate.Format("2006-01-02")),
+                    })
+                }
+            }
+        }
+        latest := series[len(series)-1]
+        if v.MaxAge > 0 && asOf.Sub(latest.EffectiveDate) > v.MaxAge {
+            report.Issues = append(report.Issues, RateIssue{
+                Kind:    IssueStale,
+                Pairs:   []CurrencyPair{pair},
+                Date:    latest.EffectiveDate,
+                Message: fmt.Sprintf("latest rate for %s is from %s", pair, latest.EffectiveDate.Format("2006-01-02")),
+            })
+        }
+    }
+    return report
+}
+
+// checkCycles finds round trips and triangles among the rates of one day
+func (v *RateValidator) checkCycles(rates map[CurrencyPair]RateInfo, tolerance float64) []RateIssue {
+    var issues []RateIssue
+    var date time.Time
+    neighbours := make(map[string]map[string]bool)
+    for pair, info := range rates {
+        date = info.EffectiveDate
+        for _, edge := range [][2]string{{pair.From, pair.To}, {pair.To, pair.From}} {
+            if neighbours[edge[0]] == nil {
+                neighbours[edge[0]] = make(map[string]bool)
+            }
+            neighbours[edge[0]][edge[1]] = true
+        }
+    }
+
+    // rate returns the quoted rate for a pair, or the inverse of the opposite quote
+    rate := func(from, to string) *big.Rat {
+        if info, ok := rates[CurrencyPair{From: from, To: to}]; ok {
+            return info.Rate.rat()
+        }
+        return rates[CurrencyPair{From: to, To: from}].Rate.Inverse().rat()
+    }
+    cycleIssue := func(kind RateIssueKind, codes ...string) {
+        product := big.NewRat(1, 1)
+        pairs := make([]CurrencyPair, len(codes))
+        for i, from := range codes {
+            to := codes[(i+1)%len(codes)]
+            pairs[i] = CurrencyPair{From: from, To: to}
+            product.Mul(product, rate(from, to))
+        }
+        if deviation := relativeDeviation(product); deviation > tolerance {
+            issues = append(issues, RateIssue{
+                Kind:      kind,
+                Pairs:     pairs,
+                Date:      date,
+                Deviation: deviation,
+                Message: fmt.Sprintf("%s cycle %v multiplies to %s on %s", kind, pairs,
+                    product.FloatString(6), date.Format("2006-01-02")),
+            })
+        }
+    }
+
+    codes := make([]string, 0, len(neighbours))
+    for code := range neighbours {
+        codes = append(codes, code)
+    }
+    sort.Strings(codes)
+    for i, a := range codes {
+        for _, b := range codes[i+1:] {
+            if !neighbours[a][b] {
+                continue
+            }
+            _, forward := rates[CurrencyPair{From: a, To: b}]
+            _, backward := rates[CurrencyPair{From: b, To: a}]
+            if forward && backward {
+                cycleIssue(IssueRoundTrip, a, b)
+            }
+            for _, c := range codes {
+                if c > b && neighbours[b][c] && neighbours[a][c] {
+                    cycleIssue(IssueTriangular, a, b, c)
+                }
+            }
+        }
+    }
+    return issues
+}
+
+// relativeDeviation returns |x - 1|
+func relativeDeviation(x *big.Rat) float64 {
+    f, _ := x.Float64()
+    return math.Abs(f - 1)
+}
+
+// dayKey identifies the calendar day of a date
+func dayKey(date time.Time) int {
+    year, month, day := date.Date()
+    return year*10000 + int(month)*100 + day
+}
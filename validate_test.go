@@ -0,0 +1,150 @@
+package money
+
+import (
+    "math"
+    "testing"
+    "time"
+)
+
+// rateOn returns a RateInfo for a pair effective on date
+func rateOn(from, to, rate string, date time.Time) RateInfo {
+    return RateInfo{From: from, To: to, Rate: MustParseRate(rate), EffectiveDate: date}
+}
+
+func TestRateValidator(t *testing.T) {
+    first, second := day(2024, 1, 2), day(2024, 1, 3)
+
+    tests := []struct {
+        name      string
+        validator RateValidator
+        rates     []RateInfo
+        want      []RateIssueKind
+        wantPairs []CurrencyPair // Pairs of the first issue, when checked
+    }{
+        {
+            name:  "consistent triangle",
+            rates: []RateInfo{rateOn("EUR", "USD", "1.1", first), rateOn("USD", "JPY", "150", first), rateOn("EUR", "JPY", "165", first)},
+        },
+        {
+            name:      "inconsistent triangle",
+            rates:     []RateInfo{rateOn("EUR", "USD", "1.1", first), rateOn("USD", "JPY", "150", first), rateOn("EUR", "JPY", "170", first)},
+            want:      []RateIssueKind{IssueTriangular},
+            wantPairs: []CurrencyPair{{From: "EUR", To: "JPY"}, {From: "JPY", To: "USD"}, {From: "USD", To: "EUR"}},
+        },
+        {
+            name:      "triangle within a wider tolerance",
+            validator: RateValidator{Tolerance: 0.05},
+            rates:     []RateInfo{rateOn("EUR", "USD", "1.1", first), rateOn("USD", "JPY", "150", first), rateOn("EUR", "JPY", "170", first)},
+        },
+        {
+            name:  "triangle across days is not checked",
+            rates: []RateInfo{rateOn("EUR", "USD", "1.1", first), rateOn("USD", "JPY", "150", first), rateOn("EUR", "JPY", "170", second)},
+        },
+        {
+            name:  "exact round trip",
+            rates: []RateInfo{rateOn("EUR", "USD", "1.1", first), rateOn("USD", "EUR", "10/11", first)},
+        },
+        {
+            name:      "inconsistent round trip",
+            rates:     []RateInfo{rateOn("EUR", "USD", "1.1", first), rateOn("USD", "EUR", "0.9", first)},
+            want:      []RateIssueKind{IssueRoundTrip},
+            wantPairs: []CurrencyPair{{From: "EUR", To: "USD"}, {From: "USD", To: "EUR"}},
+        },
+        {
+            name:      "non-positive rates are reported and skipped",
+            rates:     []RateInfo{rateOn("EUR", "USD", "0", first), {From: "GBP", To: "USD", EffectiveDate: first}, rateOn("USD", "EUR", "0.9", first)},
+            want:      []RateIssueKind{IssueNonPositive, IssueNonPositive},
+            wantPairs: []CurrencyPair{{From: "EUR", To: "USD"}},
+        },
+        {
+            name:      "jump between days, in any input order",
+            validator: RateValidator{MaxJump: 0.05},
+            rates:     []RateInfo{rateOn("EUR", "USD", "1.2", second), rateOn("EUR", "USD", "1.1", first)},
+            want:      []RateIssueKind{IssueJump},
+        },
+        {
+            name:      "change within MaxJump",
+            validator: RateValidator{MaxJump: 0.1},
+            rates:     []RateInfo{rateOn("EUR", "USD", "1.1", first), rateOn("EUR", "USD", "1.2", second)},
+        },
+        {
+            name:      "stale pair",
+            validator: RateValidator{MaxAge: 48 * time.Hour, AsOf: day(2024, 1, 4).Add(time.Hour)},
+            rates:     []RateInfo{rateOn("EUR", "USD", "1.1", first), rateOn("GBP", "USD", "1.25", second)},
+            want:      []RateIssueKind{IssueStale},
+            wantPairs: []CurrencyPair{{From: "EUR", To: "USD"}},
+        },
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            report := tt.validator.Validate(tt.rates)
+            if report.Checked != len(tt.rates) {
+                t.Errorf("Checked = %d, want %d", report.Checked, len(tt.rates))
+            }
+            if report.OK() != (len(tt.want) == 0) || len(report.Issues) != len(tt.want) {
+                t.Fatalf("Validate() issues = %+v, want %v", report.Issues, tt.want)
+            }
+            for i, kind := range tt.want {
+                if report.Issues[i].Kind != kind || report.Issues[i].Message == "" {
+                    t.Errorf("issue %d = %+v, want %v", i, report.Issues[i], kind)
+                }
+            }
+            if tt.wantPairs == nil {
+                return
+            }
+            pairs := report.Issues[0].Pairs
+            if len(pairs) != len(tt.wantPairs) {
+                t.Fatalf("Pairs = %v, want %v", pairs, tt.wantPairs)
+            }
+            for i := range pairs {
+                if pairs[i] != tt.wantPairs[i] {
+                    t.Errorf("Pairs = %v, want %v", pairs, tt.wantPairs)
+                }
+            }
+        })
+    }
+}
+
+func TestRateValidatorDeviation(t *testing.T) {
+    validator := RateValidator{MaxJump: 0.05}
+    report := validator.Validate([]RateInfo{
+        rateOn("EUR", "USD", "1.1", day(2024, 1, 2)),
+        rateOn("USD", "EUR", "0.9", day(2024, 1, 2)),
+        rateOn("EUR", "USD", "1.21", day(2024, 1, 3)),
+    })
+    if len(report.Issues) != 2 {
+        t.Fatalf("Validate() issues = %+v", report.Issues)
+    }
+    for i, want := range []struct {
+        kind      RateIssueKind
+        deviation float64
+        date      time.Time
+    }{
+        {IssueRoundTrip, 0.01, day(2024, 1, 2)},
+        {IssueJump, 0.1, day(2024, 1, 3)},
+    } {
+        issue := report.Issues[i]
+        if issue.Kind != want.kind || math.Abs(issue.Deviation-want.deviation) > 1e-9 || !issue.Date.Equal(want.date) {
+            t.Errorf("issue %d = %+v, want %v deviation %v on %v", i, issue, want.kind, want.deviation, want.date)
+        }
+    }
+}
+
+func TestRateIssueKindString(t *testing.T) {
+    tests := []struct {
+        kind RateIssueKind
+        want string
+    }{
+        {IssueNonPositive, "non-positive"},
+        {IssueRoundTrip, "round-trip"},
+        {IssueTriangular, "triangular"},
+        {IssueJump, "jump"},
+        {IssueStale, "stale"},
+        {RateIssueKind(9), "RateIssueKind(9)"},
+    }
+    for _, tt := range tests {
+        if got := tt.kind.String(); got != tt.want {
+            t.Errorf("String() = %q, want %q", got, tt.want)
+        }
+    }
+}